@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"math"
+)
+
+// AllPairs holds the shortest path distance between every pair of nodes
+// Keys gives the row and column order of the matrices and Index maps a key back to its position
+// Dist[i][j] is the distance from Keys[i] to Keys[j], math.Inf(1) if unreachable
+// Next[i][j] is the index of the node after Keys[i] on the shortest path to Keys[j], -1 if unreachable
+type AllPairs[K comparable] struct {
+	Keys  []K
+	Index map[K]int
+	Dist  [][]float64
+	Next  [][]int
+}
+
+func (g *Graph[K, V]) newAllPairs() *AllPairs[K] {
+	n := len(g.Nodes)
+	ap := &AllPairs[K]{
+		Keys:  make([]K, 0, n),
+		Index: make(map[K]int, n),
+		Dist:  make([][]float64, n),
+		Next:  make([][]int, n),
+	}
+	for k := range g.Nodes {
+		ap.Index[k] = len(ap.Keys)
+		ap.Keys = append(ap.Keys, k)
+	}
+	for i := range n {
+		ap.Dist[i] = make([]float64, n)
+		ap.Next[i] = make([]int, n)
+		for j := range n {
+			ap.Dist[i][j] = math.Inf(1)
+			ap.Next[i][j] = -1
+		}
+		ap.Dist[i][i] = 0
+		ap.Next[i][i] = i
+	}
+	return ap
+}
+
+// Returns the shortest path distance from a to b
+// Returns math.Inf(1) if b is unreachable or either node is unknown
+func (ap *AllPairs[K]) Distance(a, b K) float64 {
+	i, iExists := ap.Index[a]
+	j, jExists := ap.Index[b]
+	if !iExists || !jExists {
+		return math.Inf(1)
+	}
+	return ap.Dist[i][j]
+}
+
+// Rebuilds the shortest path from a to b using the next-hop table
+// Returns false if b is unreachable or either node is unknown
+// Examples
+// ap, _ := g.FloydWarshall()
+// path, ok := ap.Path("A", "C")
+// fmt.Println(path, ok) // Output: [A B C] true
+func (ap *AllPairs[K]) Path(a, b K) ([]K, bool) {
+	i, iExists := ap.Index[a]
+	j, jExists := ap.Index[b]
+	if !iExists || !jExists || ap.Next[i][j] == -1 {
+		return nil, false
+	}
+	path := []K{a}
+	for i != j {
+		i = ap.Next[i][j]
+		path = append(path, ap.Keys[i])
+	}
+	return path, true
+}
+
+// Returns the shortest paths between every pair of nodes using the Floyd-Warshall algorithm
+// Runs in O(n^3) time, which suits small or dense graphs
+// Negative edge weights are allowed, a negative cycle returns a *NegativeCycleError
+// Examples
+// g := New[string, int]("MyGraph", true)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 1)
+// ap, err := g.FloydWarshall()
+// fmt.Println(ap.Distance("A", "C")) // Output: 2
+func (g *Graph[K, V]) FloydWarshall() (*AllPairs[K], error) {
+	ap := g.newAllPairs()
+	for u, edges := range g.Edges {
+		i := ap.Index[u]
+		for v, weight := range edges {
+			j := ap.Index[v]
+			if weight < ap.Dist[i][j] {
+				ap.Dist[i][j] = weight
+				ap.Next[i][j] = j
+			}
+		}
+	}
+
+	n := len(ap.Keys)
+	for k := range n {
+		for i := range n {
+			if math.IsInf(ap.Dist[i][k], 1) {
+				continue
+			}
+			for j := range n {
+				if newDist := ap.Dist[i][k] + ap.Dist[k][j]; newDist < ap.Dist[i][j] {
+					ap.Dist[i][j] = newDist
+					ap.Next[i][j] = ap.Next[i][k]
+				}
+			}
+		}
+	}
+
+	for i := range n {
+		if ap.Dist[i][i] < 0 {
+			// Let Bellman-Ford name the cycle
+			_, err := g.BellmanFord(ap.Keys[i])
+			return nil, err
+		}
+	}
+	return ap, nil
+}
+
+// Returns the shortest paths between every pair of nodes using Johnson's algorithm
+// Edges are reweighted with Bellman-Ford potentials so Dijkstra can run from every node,
+// which is faster than FloydWarshall on sparse graphs even with negative edge weights
+// A negative cycle returns a *NegativeCycleError
+// Examples
+// ap, err := g.Johnson()
+// path, _ := ap.Path("A", "C")
+// fmt.Println(path) // Output: [A B C]
+func (g *Graph[K, V]) Johnson() (*AllPairs[K], error) {
+	// Starting every node at 0 is the same as adding a virtual source with a zero edge to each node
+	potential := make(map[K]float64, len(g.Nodes))
+	for k := range g.Nodes {
+		potential[k] = 0
+	}
+	if err := g.relaxEdges(potential, make(map[K]K)); err != nil {
+		return nil, err
+	}
+	reweight := func(u, v K, w float64) float64 {
+		// Clamp float noise so Dijkstra never sees a negative edge
+		return max(0, w+potential[u]-potential[v])
+	}
+
+	ap := g.newAllPairs()
+	for _, source := range ap.Keys {
+		i := ap.Index[source]
+		dist, parents, order := dijkstraTree(g.Edges, source, reweight, nil)
+		for _, v := range order[1:] {
+			j := ap.Index[v]
+			ap.Dist[i][j] = dist[v] - potential[source] + potential[v]
+			// Nodes are settled after their parent, so the parent's next hop is already known
+			if parent := parents[v]; parent == source {
+				ap.Next[i][j] = j
+			} else {
+				ap.Next[i][j] = ap.Next[i][ap.Index[parent]]
+			}
+		}
+	}
+	return ap, nil
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAllPairsAgree(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	// Only edges from lower to higher keys, so negative weights can't form a cycle
+	g := randomGraph{n: 30, p: 0.15, directed: true, acyclic: true, weight: func(rng *rand.Rand) float64 {
+		return float64(rng.Intn(20) - 5)
+	}}.build(rng)
+
+	fw, err := g.FloydWarshall()
+	if err != nil {
+		t.Fatalf("FloydWarshall failed: %v", err)
+	}
+	johnson, err := g.Johnson()
+	if err != nil {
+		t.Fatalf("Johnson failed: %v", err)
+	}
+	for source := range g.Nodes {
+		sp, err := g.BellmanFord(source)
+		if err != nil {
+			t.Fatalf("BellmanFord failed: %v", err)
+		}
+		for target, want := range sp.Dist {
+			if got := fw.Distance(source, target); got != want {
+				t.Errorf("FloydWarshall %d->%d: got %f, expected %f", source, target, got, want)
+			}
+			if got := johnson.Distance(source, target); math.Abs(got-want) > 1e-9 && !(math.IsInf(got, 1) && math.IsInf(want, 1)) {
+				t.Errorf("Johnson %d->%d: got %f, expected %f", source, target, got, want)
+			}
+			for name, ap := range map[string]*AllPairs[int]{"FloydWarshall": fw, "Johnson": johnson} {
+				path, ok := ap.Path(source, target)
+				if ok != !math.IsInf(want, 1) {
+					t.Errorf("%s %d->%d: path found %v, expected reachable %v", name, source, target, ok, !math.IsInf(want, 1))
+					continue
+				}
+				if ok && g.pathCost(path) != want {
+					t.Errorf("%s %d->%d: path %v costs %f, expected %f", name, source, target, path, g.pathCost(path), want)
+				}
+			}
+		}
+	}
+}
+
+func TestAllPairsNegativeCycle(t *testing.T) {
+	g := New[string, int]("cycle", true)
+	for _, k := range []string{"A", "B", "C"} {
+		g.AddNode(k, 0)
+	}
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", -3)
+	g.AddEdge("C", "A", 1)
+
+	var cycleErr *NegativeCycleError[string]
+	if _, err := g.FloydWarshall(); !errors.As(err, &cycleErr) {
+		t.Errorf("FloydWarshall: expected NegativeCycleError, got %v", err)
+	}
+	if _, err := g.Johnson(); !errors.As(err, &cycleErr) {
+		t.Errorf("Johnson: expected NegativeCycleError, got %v", err)
+	}
+	if len(cycleErr.Cycle) != 3 {
+		t.Errorf("Expected a cycle of 3 nodes, got %v", cycleErr.Cycle)
+	}
+}
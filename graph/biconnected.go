@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"errors"
+)
+
+var errDirectedBiconnected = errors.New("articulation points and bridges need an undirected graph")
+
+// Everything found by one pass of Tarjan's biconnectivity DFS
+type biconnectivity[K comparable] struct {
+	points     []K
+	bridges    []Edge[K]
+	components [][]K
+}
+
+// Runs Tarjan's lowpoint DFS over every connected component of an undirected graph
+// low[v] is the earliest discovery time reachable from the subtree of v using at most one back edge
+// A child u of p with low[u] >= disc[p] closes a biconnected component at p, with low[u] > disc[p] the edge p-u is a bridge
+func (g *Graph[K, V]) biconnectivity() (*biconnectivity[K], error) {
+	if g.IsDirected {
+		return nil, errDirectedBiconnected
+	}
+	res := &biconnectivity[K]{points: make([]K, 0), bridges: make([]Edge[K], 0), components: make([][]K, 0)}
+	disc := make(map[K]int, len(g.Nodes))
+	low := make(map[K]int, len(g.Nodes))
+	isPoint := make(map[K]bool)
+	// Tree and back edges not yet assigned to a component
+	edges := make([]Edge[K], 0)
+	time := 0
+	for root := range g.Nodes {
+		if _, seen := disc[root]; seen {
+			continue
+		}
+		disc[root], low[root] = time, time
+		time++
+		rootChildren := 0
+		calls := []dfsFrame[K]{newDFSFrame(root, g.Edges)}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(f.neighbors) {
+				w := f.neighbors[f.next]
+				f.next++
+				// A graph holds one edge per pair, so the edge back to the parent is always the tree edge
+				if w == f.node || (len(calls) > 1 && calls[len(calls)-2].node == w) {
+					continue
+				}
+				if d, seen := disc[w]; seen {
+					if d < disc[f.node] {
+						low[f.node] = min(low[f.node], d)
+						edges = append(edges, Edge[K]{From: f.node, To: w, Weight: g.Edges[f.node][w]})
+					}
+					continue
+				}
+				disc[w], low[w] = time, time
+				time++
+				edges = append(edges, Edge[K]{From: f.node, To: w, Weight: g.Edges[f.node][w]})
+				if len(calls) == 1 {
+					rootChildren++
+				}
+				calls = append(calls, newDFSFrame(w, g.Edges))
+				continue
+			}
+
+			u := f.node
+			calls = calls[:len(calls)-1]
+			if len(calls) == 0 {
+				break
+			}
+			p := calls[len(calls)-1].node
+			low[p] = min(low[p], low[u])
+			if low[u] > disc[p] {
+				res.bridges = append(res.bridges, Edge[K]{From: p, To: u, Weight: g.Edges[p][u]})
+			}
+			if low[u] >= disc[p] {
+				if p != root || rootChildren > 1 {
+					if !isPoint[p] {
+						isPoint[p] = true
+						res.points = append(res.points, p)
+					}
+				}
+				// Every edge pushed since the tree edge p-u belongs to the component hanging below p
+				members := make(map[K]bool)
+				component := make([]K, 0)
+				for {
+					e := edges[len(edges)-1]
+					edges = edges[:len(edges)-1]
+					for _, k := range []K{e.From, e.To} {
+						if !members[k] {
+							members[k] = true
+							component = append(component, k)
+						}
+					}
+					if e.From == p && e.To == u {
+						break
+					}
+				}
+				res.components = append(res.components, component)
+			}
+		}
+	}
+	return res, nil
+}
+
+// Returns the articulation points of an undirected graph: the nodes whose removal splits their connected component
+// Returns an error for a directed graph
+// Examples
+// g := New[string, int]("corridor", false)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 1)
+// points, err := g.ArticulationPoints()
+// fmt.Println(points) // Output: [B]
+func (g *Graph[K, V]) ArticulationPoints() ([]K, error) {
+	b, err := g.biconnectivity()
+	if err != nil {
+		return nil, err
+	}
+	return b.points, nil
+}
+
+// Returns the bridges of an undirected graph: the edges whose removal splits their connected component
+// Each bridge is returned once
+// Returns an error for a directed graph
+// Examples
+// bridges, err := g.Bridges()
+// fmt.Println(len(bridges)) // Output: 2
+func (g *Graph[K, V]) Bridges() ([]Edge[K], error) {
+	b, err := g.biconnectivity()
+	if err != nil {
+		return nil, err
+	}
+	return b.bridges, nil
+}
+
+// Returns the biconnected components of an undirected graph, each as a slice of node keys
+// Within a component no single node removal disconnects the rest; a bridge forms a component of its two ends
+// Components overlap at articulation points, and isolated nodes belong to none
+// Returns an error for a directed graph
+// Examples
+// components, err := g.BiconnectedComponents()
+// fmt.Println(len(components)) // Output: 2
+func (g *Graph[K, V]) BiconnectedComponents() ([][]K, error) {
+	b, err := g.biconnectivity()
+	if err != nil {
+		return nil, err
+	}
+	return b.components, nil
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"main.go/helpers"
+)
+
+// Two triangles sharing node 2, a tail 4-5 with a self loop at 5 and an isolated node 6
+func newBowtieGraph() *Graph[int, int] {
+	g := New[int, int]("bowtie", false)
+	for i := 0; i < 7; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 2, 1)
+	g.AddEdge(4, 5, 2)
+	g.AddEdge(5, 5, 1)
+	return g
+}
+
+func TestArticulationPoints(t *testing.T) {
+	points, err := newBowtieGraph().ArticulationPoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(points)
+	if want := []int{2, 4}; !slices.Equal(points, want) {
+		t.Errorf("ArticulationPoints %v, expected %v", points, want)
+	}
+	if _, err := New[int, int]("directed", true).ArticulationPoints(); err == nil {
+		t.Error("Expected an error for a directed graph")
+	}
+}
+
+func TestBridges(t *testing.T) {
+	bridges, err := newBowtieGraph().Bridges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bridges) != 1 {
+		t.Fatalf("Bridges %v, expected one", bridges)
+	}
+	b := bridges[0]
+	if min(b.From, b.To) != 4 || max(b.From, b.To) != 5 || b.Weight != 2 {
+		t.Errorf("Bridge %v, expected 4-5 with weight 2", b)
+	}
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	components, err := newBowtieGraph().BiconnectedComponents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"[0 1 2]", "[2 3 4]", "[4 5]"}
+	if got := normalizeComponents(components); !slices.Equal(got, want) {
+		t.Errorf("BiconnectedComponents %v, expected %v", got, want)
+	}
+}
+
+func TestBiconnectivityGrid(t *testing.T) {
+	// Two rooms split by a wall column with a single door, deep enough that a recursive DFS would be risky
+	size := 200
+	matrix := make([][]float64, size)
+	for i := range matrix {
+		matrix[i] = make([]float64, size+1)
+		for j := range matrix[i] {
+			matrix[i][j] = 1
+		}
+		if i != size/2 {
+			matrix[i][size/2] = -1
+		}
+	}
+	g := NewGraphFromMatrix("rooms", matrix, false)
+	points, err := g.ArticulationPoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, 0, len(points))
+	for _, p := range points {
+		got = append(got, fmt.Sprint(p))
+	}
+	slices.Sort(got)
+	door := func(y int) string { return fmt.Sprint(helpers.Coordinate{X: float64(size / 2), Y: float64(y)}) }
+	want := []string{door(size/2 - 1), door(size / 2), door(size/2 + 1)}
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("ArticulationPoints %v, expected %v", got, want)
+	}
+	bridges, _ := g.Bridges()
+	if len(bridges) != 2 {
+		t.Errorf("Found %d bridges, expected the 2 edges through the door", len(bridges))
+	}
+	components, _ := g.BiconnectedComponents()
+	if len(components) != 4 {
+		t.Errorf("Found %d biconnected components, expected 2 rooms and 2 bridges", len(components))
+	}
+}
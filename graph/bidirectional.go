@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"slices"
+
+	"main.go/helpers"
+)
+
+// One half of a bidirectional search
+// adjacency is g.Edges for the forward half and the reversed edges for the backward half
+type searchFrontier[K comparable] struct {
+	adjacency map[K]map[K]float64
+	dist      map[K]float64
+	parents   map[K]K
+	settled   map[K]bool
+	pq        helpers.PriorityQueue[K]
+}
+
+func newSearchFrontier[K comparable](root K, adjacency map[K]map[K]float64) *searchFrontier[K] {
+	f := &searchFrontier[K]{
+		adjacency: adjacency,
+		dist:      map[K]float64{root: 0},
+		parents:   make(map[K]K),
+		settled:   make(map[K]bool),
+		pq:        make(helpers.PriorityQueue[K], 0),
+	}
+	f.pq.PushItem(root, 0)
+	return f
+}
+
+// Returns the smallest key left in the queue, stale entries only make it smaller
+func (f *searchFrontier[K]) top() float64 {
+	return f.pq[0].Priority
+}
+
+// Returns a path from start to end using Dijkstra's algorithm from both ends at once
+// The forward search follows edges from start while the backward search follows them into end,
+// and the searches stop once no shorter meeting point can exist
+// Returns a shortest path with the same cost as Dijkstra's, though when costs tie it may be a different one
+// Visited holds the nodes settled by either search, usually far fewer than Dijkstra visits
+// Examples
+// g := New("MyGraph", true)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 2)
+// g.AddEdge("B", "C", 2)
+// g.AddEdge("A", "C", 2)
+// path, visited, err := g.BidirectionalDijkstra("A", "C")
+//
+//	if err != nil {
+//	    fmt.Println(err)
+//	} else {
+//	    fmt.Println("Path:", path) // Output: Path: [A C]
+//	    fmt.Println("Visited:", visited) // Output: Visited: [A C]
+//	}
+func (g *Graph[K, V]) BidirectionalDijkstra(start, end K) ([]K, []K, error) {
+	res, err := g.BidirectionalDijkstraResult(start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Path, res.Visited, nil
+}
+
+// Same as BidirectionalDijkstra but returns the full SearchResult
+// Visited is the order in which either search settled a node
+// Parents holds the forward search tree plus the end of Path found by the backward search
+func (g *Graph[K, V]) BidirectionalDijkstraResult(start, end K) (*SearchResult[K], error) {
+	return g.bidirectional(start, end, func(k K) float64 { return 0 })
+}
+
+// Returns a path from start to end using A* from both ends at once
+// The heuristic is used through the average of the forward and backward estimates
+// so both searches agree on edge costs and the result stays optimal
+// heuristic(a, b) must be symmetric and consistent, like helpers.EuclideanDistance on a grid
+// Returns a path with the same cost as AStar's, though when costs tie it may be a different one
+// Visited holds the nodes settled by either search
+// Examples
+// path, visited, err := g.BidirectionalAStar(start, end, helpers.EuclideanDistance)
+func (g *Graph[K, V]) BidirectionalAStar(start, end K, heuristic func(a, b K) float64) ([]K, []K, error) {
+	res, err := g.BidirectionalAStarResult(start, end, heuristic)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Path, res.Visited, nil
+}
+
+// Same as BidirectionalAStar but returns the full SearchResult
+func (g *Graph[K, V]) BidirectionalAStarResult(start, end K, heuristic func(a, b K) float64) (*SearchResult[K], error) {
+	potential := func(k K) float64 {
+		return (heuristic(k, end) - heuristic(start, k)) / 2
+	}
+	return g.bidirectional(start, end, potential)
+}
+
+// Runs bidirectional Dijkstra on edge weights reduced by potential
+// Every edge u->v costs weight - potential(u) + potential(v) in both directions,
+// which shifts all start to end paths by the same amount and keeps the shortest one shortest
+func (g *Graph[K, V]) bidirectional(start, end K, potential func(k K) float64) (*SearchResult[K], error) {
+	res := newSearchResult[K]()
+	if start == end {
+		res.Path = []K{start}
+		res.Visited = append(res.Visited, start)
+		return res, nil
+	} else if !g.ContainsNode(start) || !g.ContainsNode(end) {
+		return nil, errors.New("start or end node not in graph")
+	}
+
+	forward := newSearchFrontier(start, g.Edges)
+	backward := newSearchFrontier(end, g.reverseEdges())
+	seen := make(map[K]bool)
+	best := math.Inf(1)
+	var meet K
+
+	for forward.pq.Len() > 0 && backward.pq.Len() > 0 {
+		// Stopping rule: no path through an unsettled node can beat best
+		if forward.top()+backward.top() >= best {
+			break
+		}
+		f, other, isForward := forward, backward, true
+		if backward.top() < forward.top() {
+			f, other, isForward = backward, forward, false
+		}
+
+		u := f.pq.PopItem()
+		if f.settled[u] {
+			continue
+		}
+		f.settled[u] = true
+		if !seen[u] {
+			seen[u] = true
+			res.Visited = append(res.Visited, u)
+		}
+		for v, weight := range f.adjacency[u] {
+			if f.settled[v] {
+				continue
+			}
+			reduced := weight - potential(u) + potential(v)
+			if !isForward {
+				reduced = weight - potential(v) + potential(u)
+			}
+			newDist := f.dist[u] + reduced
+			if old, exists := f.dist[v]; exists && newDist >= old {
+				continue
+			}
+			f.dist[v] = newDist
+			f.parents[v] = u
+			f.pq.PushItem(v, newDist)
+			if otherDist, exists := other.dist[v]; exists && newDist+otherDist < best {
+				best = newDist + otherDist
+				meet = v
+			}
+		}
+	}
+	if math.IsInf(best, 1) {
+		return res, errors.New("no path found")
+	}
+
+	maps.Copy(res.Parents, forward.parents)
+	res.Path = constructPath(res.Parents, start, meet)
+	// The backward parents point towards end
+	tail := constructPath(backward.parents, end, meet)
+	slices.Reverse(tail)
+	for i := 1; i < len(tail); i++ {
+		res.Parents[tail[i]] = tail[i-1]
+	}
+	res.Path = append(res.Path, tail[1:]...)
+	res.Cost = g.pathCost(res.Path)
+	return res, nil
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+
+	"main.go/helpers"
+)
+
+func TestBidirectionalMatchesDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, directed := range []bool{true, false} {
+		g := randomGraph{n: 60, p: 0.05, directed: directed, weight: func(rng *rand.Rand) float64 {
+			return float64(1 + rng.Intn(9))
+		}}.build(rng)
+		for i := 0; i < 100; i++ {
+			start, end := rng.Intn(60), rng.Intn(60)
+			want, wantErr := g.DijkstraResult(start, end)
+			got, err := g.BidirectionalDijkstraResult(start, end)
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("directed=%v %d->%d: got error %v, Dijkstra error %v", directed, start, end, err, wantErr)
+			}
+			if err != nil {
+				continue
+			}
+			if got.Cost != want.Cost || got.Path[0] != start || got.Path[len(got.Path)-1] != end {
+				t.Errorf("directed=%v %d->%d: got %v cost %f, Dijkstra cost %f", directed, start, end, got.Path, got.Cost, want.Cost)
+			}
+			if got.Cost != g.pathCost(got.Path) {
+				t.Errorf("directed=%v %d->%d: path %v does not cost %f", directed, start, end, got.Path, got.Cost)
+			}
+		}
+	}
+}
+
+func TestBidirectionalGrid(t *testing.T) {
+	matrix := make([][]float64, 40)
+	for i := range matrix {
+		matrix[i] = make([]float64, 40)
+		for j := range matrix[i] {
+			matrix[i][j] = 1
+		}
+	}
+	g := NewGraphFromMatrix("grid", matrix, false)
+	start := helpers.Coordinate{X: 20, Y: 5}
+	end := helpers.Coordinate{X: 20, Y: 35}
+
+	_, dVisited, err := g.Dijkstra(start, end)
+	if err != nil {
+		t.Fatalf("Dijkstra failed: %v", err)
+	}
+	path, bVisited, err := g.BidirectionalDijkstra(start, end)
+	if err != nil {
+		t.Fatalf("BidirectionalDijkstra failed: %v", err)
+	}
+	if len(path) != 31 {
+		t.Errorf("BidirectionalDijkstra found path of length %d, expected 31", len(path))
+	}
+	if len(bVisited) >= len(dVisited) {
+		t.Errorf("BidirectionalDijkstra visited %d nodes, expected less than Dijkstra's %d", len(bVisited), len(dVisited))
+	}
+
+	path, aVisited, err := g.BidirectionalAStar(start, end, helpers.EuclideanDistance)
+	if err != nil {
+		t.Fatalf("BidirectionalAStar failed: %v", err)
+	}
+	if len(path) != 31 || g.pathCost(path) != 30 {
+		t.Errorf("BidirectionalAStar found path of length %d, expected 31", len(path))
+	}
+	if len(aVisited) >= len(bVisited) {
+		t.Errorf("BidirectionalAStar visited %d nodes, expected less than BidirectionalDijkstra's %d", len(aVisited), len(bVisited))
+	}
+}
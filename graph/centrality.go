@@ -0,0 +1,288 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+
+	"main.go/helpers"
+)
+
+// PageRankOptions tunes PageRank, zero values pick the networkx defaults
+// Damping is the chance of following an edge instead of jumping, 0.85 by default
+// Personalization weights where a jump lands, uniform when nil; it is normalized to sum to 1
+// Tolerance bounds the L1 change per node between iterations, 1e-6 by default
+// MaxIterations caps the power iterations, 100 by default
+type PageRankOptions[K comparable] struct {
+	Damping         float64
+	Personalization map[K]float64
+	Tolerance       float64
+	MaxIterations   int
+}
+
+// Returns the PageRank of every node, the ranks sum to 1
+// Rank flows along edges in proportion to their weight, in an undirected graph along both directions
+// Rank from nodes without outgoing edges is spread like a jump, following Personalization
+// Returns an error if the ranks don't converge within MaxIterations, along with the last ranks
+// Examples
+// g := New[string, int]("web", true)
+// g.AddNode("A", 0)
+// g.AddNode("B", 0)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "A", 1)
+// ranks, err := g.PageRank(PageRankOptions[string]{})
+// fmt.Println(ranks) // Output: map[A:0.5 B:0.5]
+func (g *Graph[K, V]) PageRank(opts PageRankOptions[K]) (map[K]float64, error) {
+	n := len(g.Nodes)
+	if n == 0 {
+		return map[K]float64{}, nil
+	}
+	if opts.Damping == 0 {
+		opts.Damping = 0.85
+	}
+	if opts.Tolerance == 0 {
+		opts.Tolerance = 1e-6
+	}
+	if opts.MaxIterations == 0 {
+		opts.MaxIterations = 100
+	}
+
+	jump := make(map[K]float64, n)
+	if opts.Personalization == nil {
+		for k := range g.Nodes {
+			jump[k] = 1 / float64(n)
+		}
+	} else {
+		total := 0.0
+		for k := range g.Nodes {
+			total += opts.Personalization[k]
+		}
+		if total <= 0 {
+			return nil, fmt.Errorf("personalization must have a positive total, got %v", total)
+		}
+		for k := range g.Nodes {
+			jump[k] = opts.Personalization[k] / total
+		}
+	}
+	outWeight := make(map[K]float64, n)
+	for u, edges := range g.Edges {
+		for _, weight := range edges {
+			outWeight[u] += weight
+		}
+	}
+
+	rank := make(map[K]float64, n)
+	for k := range g.Nodes {
+		rank[k] = 1 / float64(n)
+	}
+	for i := 0; i < opts.MaxIterations; i++ {
+		dangling := 0.0
+		for k := range g.Nodes {
+			if outWeight[k] == 0 {
+				dangling += rank[k]
+			}
+		}
+		next := make(map[K]float64, n)
+		for k := range g.Nodes {
+			next[k] = (opts.Damping*dangling + 1 - opts.Damping) * jump[k]
+		}
+		for u, edges := range g.Edges {
+			if outWeight[u] == 0 {
+				continue
+			}
+			for v, weight := range edges {
+				next[v] += opts.Damping * rank[u] * weight / outWeight[u]
+			}
+		}
+		change := 0.0
+		for k := range g.Nodes {
+			change += math.Abs(next[k] - rank[k])
+		}
+		rank = next
+		if change < float64(n)*opts.Tolerance {
+			return rank, nil
+		}
+	}
+	return rank, fmt.Errorf("pagerank did not converge in %d iterations", opts.MaxIterations)
+}
+
+// Returns the degree centrality of every node: its number of neighbors divided by n-1
+// In a directed graph both incoming and outgoing edges count, see InDegreeCentrality and OutDegreeCentrality
+func (g *Graph[K, V]) DegreeCentrality() map[K]float64 {
+	if !g.IsDirected {
+		return g.OutDegreeCentrality()
+	}
+	centrality := g.OutDegreeCentrality()
+	for k, c := range g.InDegreeCentrality() {
+		centrality[k] += c
+	}
+	return centrality
+}
+
+// Returns the number of incoming edges of every node divided by n-1
+func (g *Graph[K, V]) InDegreeCentrality() map[K]float64 {
+	centrality := make(map[K]float64, len(g.Nodes))
+	for k := range g.Nodes {
+		centrality[k] = 0
+	}
+	if len(g.Nodes) <= 1 {
+		return centrality
+	}
+	scale := 1 / float64(len(g.Nodes)-1)
+	for _, edges := range g.Edges {
+		for v := range edges {
+			centrality[v] += scale
+		}
+	}
+	return centrality
+}
+
+// Returns the number of outgoing edges of every node divided by n-1
+func (g *Graph[K, V]) OutDegreeCentrality() map[K]float64 {
+	centrality := make(map[K]float64, len(g.Nodes))
+	for k := range g.Nodes {
+		centrality[k] = 0
+	}
+	if len(g.Nodes) <= 1 {
+		return centrality
+	}
+	scale := 1 / float64(len(g.Nodes)-1)
+	for u, edges := range g.Edges {
+		centrality[u] = float64(len(edges)) * scale
+	}
+	return centrality
+}
+
+// Prices every edge at 1 so Dijkstra counts hops
+func unitWeight[K comparable](u, v K, w float64) float64 {
+	return 1
+}
+
+// Returns the shortest path distances from every reachable node to target
+func (g *Graph[K, V]) distancesTo(target K, reverse map[K]map[K]float64, weighted bool) map[K]float64 {
+	weight := unitWeight[K]
+	if weighted {
+		weight = edgeWeight[K]
+	}
+	dist, _, order := dijkstraTree(reverse, target, weight, nil)
+	settled := make(map[K]float64, len(order))
+	for _, k := range order {
+		settled[k] = dist[k]
+	}
+	return settled
+}
+
+// Returns the closeness centrality of every node, based on the distances from other nodes to it
+// A node reached by r other nodes with total distance d scores (r / d) * (r / (n-1)),
+// which keeps nodes in small components from scoring highly
+// With weighted set edge weights are distances, otherwise every edge counts as 1
+func (g *Graph[K, V]) ClosenessCentrality(weighted bool) map[K]float64 {
+	centrality := make(map[K]float64, len(g.Nodes))
+	reverse := g.reverseEdges()
+	for k := range g.Nodes {
+		centrality[k] = 0
+		total := 0.0
+		dist := g.distancesTo(k, reverse, weighted)
+		for _, d := range dist {
+			total += d
+		}
+		reached := float64(len(dist) - 1)
+		if total > 0 && len(g.Nodes) > 1 {
+			centrality[k] = (reached / total) * (reached / float64(len(g.Nodes)-1))
+		}
+	}
+	return centrality
+}
+
+// Returns the harmonic centrality of every node: the sum of 1/d over the distances d from other nodes to it
+// Unreachable nodes add nothing, so unlike closeness it handles disconnected graphs directly
+// With weighted set edge weights are distances, otherwise every edge counts as 1
+func (g *Graph[K, V]) HarmonicCentrality(weighted bool) map[K]float64 {
+	centrality := make(map[K]float64, len(g.Nodes))
+	reverse := g.reverseEdges()
+	for k := range g.Nodes {
+		centrality[k] = 0
+		for other, d := range g.distancesTo(k, reverse, weighted) {
+			if other != k && d > 0 {
+				centrality[k] += 1 / d
+			}
+		}
+	}
+	return centrality
+}
+
+// Returns the betweenness centrality of every node using Brandes' algorithm
+// A node scores the fraction of shortest paths between other pairs of nodes that pass through it
+// With weighted set edge weights are distances, otherwise every edge counts as 1
+// With normalized set scores are divided by (n-1)(n-2), matching networkx
+// Examples
+// g := New[int, int]("path", false)
+// for i := 0; i < 4; i++ { g.AddNode(i, i) }
+// g.AddEdge(0, 1, 1)
+// g.AddEdge(1, 2, 1)
+// g.AddEdge(2, 3, 1)
+// fmt.Println(g.BetweennessCentrality(false, false)) // Output: map[0:0 1:2 2:2 3:0]
+func (g *Graph[K, V]) BetweennessCentrality(weighted, normalized bool) map[K]float64 {
+	centrality := make(map[K]float64, len(g.Nodes))
+	for k := range g.Nodes {
+		centrality[k] = 0
+	}
+	for s := range g.Nodes {
+		// order lists nodes by distance from s, preds and sigma count the shortest paths into each node
+		order := make([]K, 0)
+		preds := make(map[K][]K)
+		sigma := map[K]float64{s: 1}
+		dist := map[K]float64{s: 0}
+		settled := make(map[K]bool)
+		pq := make(helpers.PriorityQueue[K], 0)
+		pq.PushItem(s, 0)
+		for pq.Len() > 0 {
+			u := pq.PopItem()
+			if settled[u] {
+				continue
+			}
+			settled[u] = true
+			order = append(order, u)
+			for v, w := range g.Edges[u] {
+				if !weighted {
+					w = 1
+				}
+				newDist := dist[u] + w
+				old, seen := dist[v]
+				switch {
+				case !seen || newDist < old:
+					dist[v] = newDist
+					sigma[v] = sigma[u]
+					preds[v] = []K{u}
+					pq.PushItem(v, newDist)
+				case newDist == old && !settled[v]:
+					sigma[v] += sigma[u]
+					preds[v] = append(preds[v], u)
+				}
+			}
+		}
+
+		delta := make(map[K]float64, len(order))
+		for i := len(order) - 1; i >= 0; i-- {
+			w := order[i]
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+
+	n := float64(len(g.Nodes))
+	scale := 1.0
+	if normalized && n > 2 {
+		scale = 1 / ((n - 1) * (n - 2))
+	} else if !normalized && !g.IsDirected {
+		// Every undirected pair was counted from both ends
+		scale = 0.5
+	}
+	for k := range centrality {
+		centrality[k] *= scale
+	}
+	return centrality
+}
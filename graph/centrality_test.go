@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+// Compares every score to want within a small tolerance
+func checkScores(t *testing.T, name string, got, want map[int]float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s returned %d scores, expected %d", name, len(got), len(want))
+	}
+	for k, w := range want {
+		if math.Abs(got[k]-w) > 1e-6 {
+			t.Errorf("%s of %d is %v, expected %v", name, k, got[k], w)
+		}
+	}
+}
+
+// Undirected path 0-1-2-3 with unit weights
+func newPathGraph() *Graph[int, int] {
+	g := New[int, int]("path", false)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	return g
+}
+
+func TestPageRank(t *testing.T) {
+	g := New[int, int]("cycle", true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i, i)
+	}
+	for i := 0; i < 4; i++ {
+		g.AddEdge(i, (i+1)%4, 1)
+	}
+	ranks, err := g.PageRank(PageRankOptions[int]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkScores(t, "PageRank", ranks, map[int]float64{0: 0.25, 1: 0.25, 2: 0.25, 3: 0.25})
+
+	// 0->1->2 with every jump, including those from the dead end 2, landing on 0
+	g = New[int, int]("chain", true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	ranks, err = g.PageRank(PageRankOptions[int]{Personalization: map[int]float64{0: 1}, Tolerance: 1e-10, MaxIterations: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := 0.85
+	r0 := (1 - d) / (1 - d*d*d)
+	checkScores(t, "Personalized PageRank", ranks, map[int]float64{0: r0, 1: d * r0, 2: d * d * r0})
+
+	if _, err := g.PageRank(PageRankOptions[int]{MaxIterations: 1}); err == nil {
+		t.Error("Expected an error when PageRank does not converge")
+	}
+	if _, err := g.PageRank(PageRankOptions[int]{Personalization: map[int]float64{7: 1}}); err == nil {
+		t.Error("Expected an error for personalization without weight on any node")
+	}
+}
+
+func TestPageRankWeights(t *testing.T) {
+	// Node 0 sends three times as much rank to 1 as to 2
+	g := New[int, int]("weighted", true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 3)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 0, 1)
+	g.AddEdge(2, 0, 1)
+	ranks, err := g.PageRank(PageRankOptions[int]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0.0
+	for _, r := range ranks {
+		total += r
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("Ranks sum to %v, expected 1", total)
+	}
+	if ranks[1] <= ranks[2] {
+		t.Errorf("Expected node 1 to outrank node 2, got %v", ranks)
+	}
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	g := newPathGraph()
+	checkScores(t, "Betweenness", g.BetweennessCentrality(false, false), map[int]float64{0: 0, 1: 2, 2: 2, 3: 0})
+	checkScores(t, "Normalized betweenness", g.BetweennessCentrality(false, true), map[int]float64{0: 0, 1: 2.0 / 3, 2: 2.0 / 3, 3: 0})
+
+	// Square 0-1-3-2-0: unweighted every pair of opposite corners has two shortest paths
+	g = New[int, int]("square", false)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(3, 2, 1)
+	g.AddEdge(2, 0, 5)
+	checkScores(t, "Square betweenness", g.BetweennessCentrality(false, false), map[int]float64{0: 0.5, 1: 0.5, 2: 0.5, 3: 0.5})
+	// Weighted the heavy edge 2-0 is avoided, so 1 and 3 carry every detour
+	checkScores(t, "Weighted betweenness", g.BetweennessCentrality(true, false), map[int]float64{0: 0, 1: 2, 2: 0, 3: 2})
+
+	g = New[int, int]("chain", true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	checkScores(t, "Directed betweenness", g.BetweennessCentrality(false, true), map[int]float64{0: 0, 1: 0.5, 2: 0})
+}
+
+func TestClosenessCentrality(t *testing.T) {
+	g := newPathGraph()
+	checkScores(t, "Closeness", g.ClosenessCentrality(false), map[int]float64{0: 0.5, 1: 0.75, 2: 0.75, 3: 0.5})
+	checkScores(t, "Harmonic", g.HarmonicCentrality(false), map[int]float64{0: 11.0 / 6, 1: 2.5, 2: 2.5, 3: 11.0 / 6})
+
+	g.AddEdge(2, 3, 4)
+	checkScores(t, "Weighted closeness", g.ClosenessCentrality(true), map[int]float64{0: 3.0 / 9, 1: 3.0 / 7, 2: 3.0 / 7, 3: 3.0 / 15})
+
+	// Directed scores count the distances into a node
+	g = New[int, int]("chain", true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	checkScores(t, "Directed closeness", g.ClosenessCentrality(false), map[int]float64{0: 0, 1: 1.0 / 3, 2: 2.0 / 3 * 2.0 / 3, 3: 0})
+	checkScores(t, "Directed harmonic", g.HarmonicCentrality(false), map[int]float64{0: 0, 1: 1, 2: 1.5, 3: 0})
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	g := New[int, int]("star", false)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i, i)
+	}
+	for i := 1; i < 4; i++ {
+		g.AddEdge(0, i, 1)
+	}
+	checkScores(t, "Degree", g.DegreeCentrality(), map[int]float64{0: 1, 1: 1.0 / 3, 2: 1.0 / 3, 3: 1.0 / 3})
+
+	d := New[int, int]("star", true)
+	for i := 0; i < 4; i++ {
+		d.AddNode(i, i)
+	}
+	for i := 1; i < 4; i++ {
+		d.AddEdge(0, i, 1)
+	}
+	d.AddEdge(1, 0, 1)
+	checkScores(t, "Out degree", d.OutDegreeCentrality(), map[int]float64{0: 1, 1: 1.0 / 3, 2: 0, 3: 0})
+	checkScores(t, "In degree", d.InDegreeCentrality(), map[int]float64{0: 1.0 / 3, 1: 1.0 / 3, 2: 1.0 / 3, 3: 1.0 / 3})
+	checkScores(t, "Degree", d.DegreeCentrality(), map[int]float64{0: 4.0 / 3, 1: 2.0 / 3, 2: 1.0 / 3, 3: 1.0 / 3})
+}
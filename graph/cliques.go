@@ -0,0 +1,371 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"main.go/helpers"
+)
+
+var errDirectedClique = errors.New("cliques need an undirected graph")
+
+// Returns the keys in a fixed order and the sorted neighbor indexes of every node, self loops left out
+func (g *Graph[K, V]) indexAdjacency() ([]K, [][]int) {
+	keys := g.sortedKeys()
+	index := make(map[K]int, len(keys))
+	for i, k := range keys {
+		index[k] = i
+	}
+	adjacency := make([][]int, len(keys))
+	for i, k := range keys {
+		for v := range g.Edges[k] {
+			if v != k {
+				adjacency[i] = append(adjacency[i], index[v])
+			}
+		}
+		slices.Sort(adjacency[i])
+	}
+	return keys, adjacency
+}
+
+// Maps each index back to its key
+func indexKeys[K comparable](keys []K, indexes []int) []K {
+	result := make([]K, len(indexes))
+	for i, idx := range indexes {
+		result[i] = keys[idx]
+	}
+	return result
+}
+
+// Returns every maximal clique of an undirected graph using Bron-Kerbosch with pivoting
+// A clique is a set of nodes that are all joined to each other, it is maximal if no node can be added
+// Each step branches only on candidates not joined to a pivot, the node covering the most candidates,
+// which keeps the work close to the number of maximal cliques
+// Isolated nodes are cliques of their own, self loops are ignored
+// Returns an error for a directed graph
+// Examples
+// g := New[string, int]("units", false)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 1)
+// g.AddEdge("C", "A", 1)
+// cliques, err := g.MaximalCliques()
+// fmt.Println(len(cliques), len(cliques[0])) // Output: 1 3
+func (g *Graph[K, V]) MaximalCliques() ([][]K, error) {
+	if g.IsDirected {
+		return nil, errDirectedClique
+	}
+	cliques := make([][]K, 0)
+	candidates := make(map[K]bool, len(g.Nodes))
+	for k := range g.Nodes {
+		candidates[k] = true
+	}
+	g.bronKerbosch(nil, candidates, make(map[K]bool), &cliques)
+	return cliques, nil
+}
+
+// Reports every maximal clique that extends clique with nodes of candidates and none of excluded
+func (g *Graph[K, V]) bronKerbosch(clique []K, candidates, excluded map[K]bool, cliques *[][]K) {
+	if len(candidates) == 0 {
+		if len(excluded) == 0 {
+			*cliques = append(*cliques, slices.Clone(clique))
+		}
+		return
+	}
+	var pivot K
+	covered := -1
+	for _, set := range []map[K]bool{candidates, excluded} {
+		for u := range set {
+			count := 0
+			for v := range g.Edges[u] {
+				if candidates[v] && v != u {
+					count++
+				}
+			}
+			if count > covered {
+				pivot, covered = u, count
+			}
+		}
+	}
+	branches := make([]K, 0)
+	for v := range candidates {
+		if _, joined := g.Edges[pivot][v]; !joined || v == pivot {
+			branches = append(branches, v)
+		}
+	}
+	for _, v := range branches {
+		nextCandidates := make(map[K]bool)
+		nextExcluded := make(map[K]bool)
+		for w := range g.Edges[v] {
+			if w == v {
+				continue
+			}
+			if candidates[w] {
+				nextCandidates[w] = true
+			}
+			if excluded[w] {
+				nextExcluded[w] = true
+			}
+		}
+		g.bronKerbosch(append(clique, v), nextCandidates, nextExcluded, cliques)
+		delete(candidates, v)
+		excluded[v] = true
+	}
+}
+
+// Hands out the nodes of an index graph by lowest degree among the nodes not yet removed
+type degreeQueue struct {
+	adjacency [][]int
+	degree    []int
+	removed   []bool
+	pq        helpers.PriorityQueue[int]
+}
+
+func newDegreeQueue(adjacency [][]int) *degreeQueue {
+	q := &degreeQueue{
+		adjacency: adjacency,
+		degree:    make([]int, len(adjacency)),
+		removed:   make([]bool, len(adjacency)),
+		pq:        make(helpers.PriorityQueue[int], 0, len(adjacency)),
+	}
+	for u, neighbors := range adjacency {
+		q.degree[u] = len(neighbors)
+		q.pq.PushItem(u, float64(q.degree[u]))
+	}
+	return q
+}
+
+// Returns a remaining node of lowest degree without removing it, -1 once every node is removed
+func (q *degreeQueue) next() int {
+	for q.pq.Len() > 0 {
+		item := q.pq[0]
+		if u := item.Value; !q.removed[u] && int(item.Priority) == q.degree[u] {
+			return u
+		}
+		// Removed nodes and degrees that have since dropped leave entries behind
+		q.pq.PopItem()
+	}
+	return -1
+}
+
+// Removes u, if still there, and lowers the degrees of its remaining neighbors
+func (q *degreeQueue) remove(u int) {
+	if q.removed[u] {
+		return
+	}
+	q.removed[u] = true
+	for _, v := range q.adjacency[u] {
+		if !q.removed[v] {
+			q.degree[v]--
+			q.pq.PushItem(v, float64(q.degree[v]))
+		}
+	}
+}
+
+// Returns a largest clique of an undirected graph using branch and bound
+// Candidates are greedily colored at each step, and since a clique needs a different color per node,
+// a branch stops once the clique plus its color count can't beat the best clique found
+// The search is exponential in the worst case, GreedyClique suits large graphs
+// If ctx ends first the best clique so far is returned with an error wrapping ErrSearchInterrupted
+// Returns an error for a directed graph
+// Examples
+// clique, err := g.MaximumClique(ctx)
+// fmt.Println(len(clique)) // Output: 3
+func (g *Graph[K, V]) MaximumClique(ctx context.Context) ([]K, error) {
+	if g.IsDirected {
+		return nil, errDirectedClique
+	}
+	keys, adjacency := g.indexAdjacency()
+	joined := make([][]bool, len(keys))
+	for u, neighbors := range adjacency {
+		joined[u] = make([]bool, len(keys))
+		for _, v := range neighbors {
+			joined[u][v] = true
+		}
+	}
+	clique, err := maximumClique(ctx, joined, greedyClique(adjacency))
+	return indexKeys(keys, clique), err
+}
+
+// Returns a largest independent set of an undirected graph: nodes of which no two are joined by an edge
+// This is the maximum clique of the complement graph, solved with the same branch and bound
+// The search is exponential in the worst case, GreedyIndependentSet suits large graphs
+// If ctx ends first the best set so far is returned with an error wrapping ErrSearchInterrupted
+// Returns an error for a directed graph
+// Examples
+// set, err := g.MaximumIndependentSet(ctx)
+func (g *Graph[K, V]) MaximumIndependentSet(ctx context.Context) ([]K, error) {
+	if g.IsDirected {
+		return nil, errDirectedClique
+	}
+	keys, adjacency := g.indexAdjacency()
+	apart := make([][]bool, len(keys))
+	for u := range apart {
+		apart[u] = make([]bool, len(keys))
+		for v := range apart[u] {
+			apart[u][v] = u != v
+		}
+		for _, v := range adjacency[u] {
+			apart[u][v] = false
+		}
+	}
+	set, err := maximumClique(ctx, apart, greedyIndependentSet(adjacency))
+	return indexKeys(keys, set), err
+}
+
+// Returns a largest clique of the graph given by the adjacency matrix joined, starting from the clique best
+func maximumClique(ctx context.Context, joined [][]bool, best []int) ([]int, error) {
+	n := len(joined)
+	best = slices.Clone(best)
+	// Visit high degree nodes first so large cliques are found early
+	order := make([]int, n)
+	degree := make([]int, n)
+	for u := range order {
+		order[u] = u
+		for v := range joined[u] {
+			if joined[u][v] {
+				degree[u]++
+			}
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return degree[b] - degree[a] })
+
+	steps := 0
+	var interrupted error
+	clique := make([]int, 0)
+	var expand func(candidates []int)
+	expand = func(candidates []int) {
+		if steps%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				interrupted = fmt.Errorf("%w: %w", ErrSearchInterrupted, err)
+			}
+		}
+		steps++
+		ordered, bounds := colorBounds(joined, candidates)
+		// Take candidates from the highest color down, each with the candidates before it
+		for i := len(ordered) - 1; i >= 0 && interrupted == nil; i-- {
+			if len(clique)+bounds[i] <= len(best) {
+				return
+			}
+			v := ordered[i]
+			clique = append(clique, v)
+			next := make([]int, 0, i)
+			for _, w := range ordered[:i] {
+				if joined[v][w] {
+					next = append(next, w)
+				}
+			}
+			if len(next) == 0 {
+				if len(clique) > len(best) {
+					best = slices.Clone(clique)
+				}
+			} else {
+				expand(next)
+			}
+			clique = clique[:len(clique)-1]
+		}
+	}
+	expand(order)
+	return best, interrupted
+}
+
+// Greedily colors candidates and returns them ordered by color
+// bounds[i] is the color number, from 1, of ordered[i], the most nodes a clique among ordered[:i+1] can have
+func colorBounds(joined [][]bool, candidates []int) ([]int, []int) {
+	classes := make([][]int, 0)
+	for _, v := range candidates {
+		placed := false
+		for c, class := range classes {
+			free := true
+			for _, u := range class {
+				if joined[u][v] {
+					free = false
+					break
+				}
+			}
+			if free {
+				classes[c] = append(class, v)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			classes = append(classes, []int{v})
+		}
+	}
+	ordered := make([]int, 0, len(candidates))
+	bounds := make([]int, 0, len(candidates))
+	for c, class := range classes {
+		for _, v := range class {
+			ordered = append(ordered, v)
+			bounds = append(bounds, c+1)
+		}
+	}
+	return ordered, bounds
+}
+
+// Returns a large clique found greedily, fast enough for large graphs but not always the largest
+// Grows a clique from every node, adding its neighbors from highest degree down when they join every member
+// Returns an error for a directed graph
+func (g *Graph[K, V]) GreedyClique() ([]K, error) {
+	if g.IsDirected {
+		return nil, errDirectedClique
+	}
+	keys, adjacency := g.indexAdjacency()
+	return indexKeys(keys, greedyClique(adjacency)), nil
+}
+
+// Returns the largest of the cliques grown greedily from each node
+func greedyClique(adjacency [][]int) []int {
+	best := make([]int, 0)
+	for start := range adjacency {
+		neighbors := slices.Clone(adjacency[start])
+		slices.SortStableFunc(neighbors, func(a, b int) int { return len(adjacency[b]) - len(adjacency[a]) })
+		clique := []int{start}
+		for _, v := range neighbors {
+			joins := true
+			for _, member := range clique {
+				if _, found := slices.BinarySearch(adjacency[member], v); !found {
+					joins = false
+					break
+				}
+			}
+			if joins {
+				clique = append(clique, v)
+			}
+		}
+		if len(clique) > len(best) {
+			best = clique
+		}
+	}
+	return best
+}
+
+// Returns a large independent set found greedily, fast enough for large graphs but not always the largest
+// Repeatedly takes the node with the fewest remaining neighbors and drops those neighbors
+// Returns an error for a directed graph
+func (g *Graph[K, V]) GreedyIndependentSet() ([]K, error) {
+	if g.IsDirected {
+		return nil, errDirectedClique
+	}
+	keys, adjacency := g.indexAdjacency()
+	return indexKeys(keys, greedyIndependentSet(adjacency)), nil
+}
+
+// Returns the independent set built by taking nodes of lowest remaining degree
+func greedyIndependentSet(adjacency [][]int) []int {
+	q := newDegreeQueue(adjacency)
+	set := make([]int, 0)
+	for u := q.next(); u != -1; u = q.next() {
+		set = append(set, u)
+		q.remove(u)
+		for _, v := range adjacency[u] {
+			q.remove(v)
+		}
+	}
+	return set
+}
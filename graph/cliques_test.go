@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Returns true if every pair in nodes is joined, or none is when independent is set
+func isClique(g *Graph[int, int], nodes []int, independent bool) bool {
+	for i, u := range nodes {
+		for _, v := range nodes[i+1:] {
+			if g.ContainsEdge(u, v) == independent || u == v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns the subsets of 0..n-1 that are cliques, or independent sets, as bit masks
+func bruteForceCliques(g *Graph[int, int], independent bool) []int {
+	n := len(g.Nodes)
+	masks := make([]int, 0)
+	for mask := 1; mask < 1<<n; mask++ {
+		nodes := make([]int, 0)
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				nodes = append(nodes, i)
+			}
+		}
+		if isClique(g, nodes, independent) {
+			masks = append(masks, mask)
+		}
+	}
+	return masks
+}
+
+func TestMaximalCliques(t *testing.T) {
+	g := newUndirectedGraph(6, [][2]int{{0, 1}, {1, 2}, {2, 0}, {1, 3}, {2, 3}, {3, 4}, {4, 4}})
+	cliques, err := g.MaximalCliques()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"[0 1 2]", "[1 2 3]", "[3 4]", "[5]"}
+	if got := normalizeComponents(cliques); !slices.Equal(got, want) {
+		t.Errorf("MaximalCliques %v, expected %v", got, want)
+	}
+
+	rng := rand.New(rand.NewSource(17))
+	for round := 0; round < 10; round++ {
+		g := randomGraph{n: 10, p: 0.5}.build(rng)
+		all := bruteForceCliques(g, false)
+		maximal := 0
+		for _, mask := range all {
+			extendable := false
+			for _, other := range all {
+				if other != mask && other&mask == mask {
+					extendable = true
+					break
+				}
+			}
+			if !extendable {
+				maximal++
+			}
+		}
+		cliques, _ := g.MaximalCliques()
+		if len(cliques) != maximal {
+			t.Errorf("Found %d maximal cliques, expected %d", len(cliques), maximal)
+		}
+		for _, c := range cliques {
+			if !isClique(g, c, false) {
+				t.Errorf("%v is not a clique", c)
+			}
+		}
+	}
+}
+
+func TestMaximumClique(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	for round := 0; round < 10; round++ {
+		g := randomGraph{n: 12, p: 0.3 + 0.05*float64(round)}.build(rng)
+		for _, independent := range []bool{false, true} {
+			want := 0
+			for _, mask := range bruteForceCliques(g, independent) {
+				want = max(want, bits.OnesCount(uint(mask)))
+			}
+			var got []int
+			var err error
+			if independent {
+				got, err = g.MaximumIndependentSet(context.Background())
+			} else {
+				got, err = g.MaximumClique(context.Background())
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != want || !isClique(g, got, independent) {
+				t.Errorf("Found %v (independent %v), expected size %d", got, independent, want)
+			}
+		}
+	}
+}
+
+func TestGreedyCliqueAndIndependentSet(t *testing.T) {
+	rng := rand.New(rand.NewSource(31))
+	g := randomGraph{n: 300, p: 0.1}.build(rng)
+	clique, err := g.GreedyClique()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clique) < 2 || !isClique(g, clique, false) {
+		t.Errorf("GreedyClique gave %v", clique)
+	}
+	set, err := g.GreedyIndependentSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isClique(g, set, true) {
+		t.Fatalf("GreedyIndependentSet gave a set with an edge")
+	}
+	// Every other node must have a neighbor in the set, or it could have been added
+	for k := range g.Nodes {
+		if slices.Contains(set, k) {
+			continue
+		}
+		if !slices.ContainsFunc(set, func(s int) bool { return g.ContainsEdge(k, s) }) {
+			t.Errorf("Node %d could join the independent set", k)
+		}
+	}
+}
+
+func TestMaximumCliqueInterrupted(t *testing.T) {
+	g := randomGraph{n: 60, p: 0.5}.build(rand.New(rand.NewSource(2)))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	clique, err := g.MaximumClique(ctx)
+	if !errors.Is(err, ErrSearchInterrupted) {
+		t.Fatalf("Expected an interruption, got %v", err)
+	}
+	if len(clique) == 0 || !isClique(g, clique, false) {
+		t.Errorf("Expected the greedy clique, got %v", clique)
+	}
+	if _, err := New[int, int]("directed", true).MaximalCliques(); err == nil {
+		t.Error("Expected an error for a directed graph")
+	}
+}
@@ -0,0 +1,315 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var errDirectedColoring = errors.New("coloring needs an undirected graph")
+
+// ColoringStrategy picks the order GreedyColoring colors nodes in
+type ColoringStrategy int
+
+const (
+	// LargestFirst colors nodes by decreasing degree
+	LargestFirst ColoringStrategy = iota
+	// SmallestLast repeatedly sets aside a node of lowest degree and colors them in reverse,
+	// which never needs more than one color above the graph's degeneracy
+	SmallestLast
+	// ConnectedSequential colors nodes in BFS order, so each node after the first of its component has a colored neighbor
+	ConnectedSequential
+)
+
+// Returns the keys in a fixed order and the neighbor indexes of every node
+// Returns an error for a directed graph or a self loop, which no coloring can satisfy
+func (g *Graph[K, V]) coloringAdjacency() ([]K, [][]int, error) {
+	if g.IsDirected {
+		return nil, nil, errDirectedColoring
+	}
+	for k, edges := range g.Edges {
+		if _, loop := edges[k]; loop {
+			return nil, nil, fmt.Errorf("node %v has a self loop and can't be colored", k)
+		}
+	}
+	keys, adjacency := g.indexAdjacency()
+	return keys, adjacency, nil
+}
+
+// Maps each key to the color at its index
+func colorMap[K comparable](keys []K, color []int) map[K]int {
+	colors := make(map[K]int, len(keys))
+	for i, k := range keys {
+		colors[k] = color[i]
+	}
+	return colors
+}
+
+// Returns true if every node has a non-negative color and no edge joins two nodes of the same color
+// Examples
+// fmt.Println(g.IsValidColoring(map[string]int{"A": 0, "B": 1})) // Output: true
+func (g *Graph[K, V]) IsValidColoring(colors map[K]int) bool {
+	for k := range g.Nodes {
+		if c, exists := colors[k]; !exists || c < 0 {
+			return false
+		}
+	}
+	for u, edges := range g.Edges {
+		for v := range edges {
+			if colors[u] == colors[v] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns a coloring of an undirected graph that gives each node, in the order chosen by strategy,
+// the lowest color not used by a neighbor
+// Colors are numbered from 0, no two neighbors share a color
+// Fast but not optimal, DSatur usually needs fewer colors and ExactColoring the fewest
+// Returns an error for a directed graph or a self loop
+// Examples
+// g := New[string, int]("jobs", false)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 1)
+// colors, err := g.GreedyColoring(LargestFirst)
+// fmt.Println(colors) // Output: map[A:1 B:0 C:1]
+func (g *Graph[K, V]) GreedyColoring(strategy ColoringStrategy) (map[K]int, error) {
+	keys, adjacency, err := g.coloringAdjacency()
+	if err != nil {
+		return nil, err
+	}
+	var order []int
+	switch strategy {
+	case LargestFirst:
+		order = largestFirstOrder(adjacency)
+	case SmallestLast:
+		order = smallestLastOrder(adjacency)
+	case ConnectedSequential:
+		order = connectedSequentialOrder(adjacency)
+	default:
+		return nil, fmt.Errorf("unknown coloring strategy %d", strategy)
+	}
+	color := make([]int, len(keys))
+	for i := range color {
+		color[i] = -1
+	}
+	taken := make([]bool, len(keys)+1)
+	for _, u := range order {
+		for _, v := range adjacency[u] {
+			if color[v] >= 0 {
+				taken[color[v]] = true
+			}
+		}
+		for color[u] = 0; taken[color[u]]; color[u]++ {
+		}
+		for _, v := range adjacency[u] {
+			if color[v] >= 0 {
+				taken[color[v]] = false
+			}
+		}
+	}
+	return colorMap(keys, color), nil
+}
+
+// Returns the nodes by decreasing degree
+func largestFirstOrder(adjacency [][]int) []int {
+	order := make([]int, len(adjacency))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(adjacency[b]) - len(adjacency[a]) })
+	return order
+}
+
+// Returns the reverse of the order in which nodes of lowest remaining degree are removed
+func smallestLastOrder(adjacency [][]int) []int {
+	q := newDegreeQueue(adjacency)
+	order := make([]int, 0, len(adjacency))
+	for u := q.next(); u != -1; u = q.next() {
+		q.remove(u)
+		order = append(order, u)
+	}
+	slices.Reverse(order)
+	return order
+}
+
+// Returns the nodes in BFS order, one component after another
+func connectedSequentialOrder(adjacency [][]int) []int {
+	seen := make([]bool, len(adjacency))
+	order := make([]int, 0, len(adjacency))
+	for root := range adjacency {
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		for queue := []int{root}; len(queue) > 0; queue = queue[1:] {
+			u := queue[0]
+			order = append(order, u)
+			for _, v := range adjacency[u] {
+				if !seen[v] {
+					seen[v] = true
+					queue = append(queue, v)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// Returns a coloring of an undirected graph using DSatur
+// The next node colored is always the one whose neighbors already use the most distinct colors,
+// ties going to the node with the most uncolored neighbors, and it gets the lowest free color
+// Optimal on bipartite graphs, cycles and wheels, and usually close elsewhere
+// Returns an error for a directed graph or a self loop
+// Examples
+// colors, err := g.DSatur()
+func (g *Graph[K, V]) DSatur() (map[K]int, error) {
+	keys, adjacency, err := g.coloringAdjacency()
+	if err != nil {
+		return nil, err
+	}
+	return colorMap(keys, dsatur(adjacency)), nil
+}
+
+// Tracks how many neighbors of each node use each color, the core bookkeeping of DSatur
+type saturation struct {
+	adjacency [][]int
+	color     []int
+	// counts[u][c] is the number of neighbors of u colored c, so len(counts[u]) is the saturation of u
+	counts []map[int]int
+	// uncolored[u] is the number of neighbors of u still uncolored
+	uncolored []int
+}
+
+func newSaturation(adjacency [][]int) *saturation {
+	n := len(adjacency)
+	s := &saturation{
+		adjacency: adjacency,
+		color:     make([]int, n),
+		counts:    make([]map[int]int, n),
+		uncolored: make([]int, n),
+	}
+	for u := range adjacency {
+		s.color[u] = -1
+		s.counts[u] = make(map[int]int)
+		s.uncolored[u] = len(adjacency[u])
+	}
+	return s
+}
+
+func (s *saturation) set(u, c int) {
+	s.color[u] = c
+	for _, v := range s.adjacency[u] {
+		s.counts[v][c]++
+		s.uncolored[v]--
+	}
+}
+
+func (s *saturation) unset(u int) {
+	c := s.color[u]
+	s.color[u] = -1
+	for _, v := range s.adjacency[u] {
+		s.counts[v][c]--
+		if s.counts[v][c] == 0 {
+			delete(s.counts[v], c)
+		}
+		s.uncolored[v]++
+	}
+}
+
+// Returns the uncolored node of highest saturation, ties broken by uncolored degree, -1 if all are colored
+func (s *saturation) next() int {
+	best := -1
+	for u, c := range s.color {
+		if c >= 0 {
+			continue
+		}
+		if best == -1 || len(s.counts[u]) > len(s.counts[best]) ||
+			(len(s.counts[u]) == len(s.counts[best]) && s.uncolored[u] > s.uncolored[best]) {
+			best = u
+		}
+	}
+	return best
+}
+
+// Returns the DSatur coloring of the nodes by index
+func dsatur(adjacency [][]int) []int {
+	s := newSaturation(adjacency)
+	for u := s.next(); u != -1; u = s.next() {
+		c := 0
+		for s.counts[u][c] > 0 {
+			c++
+		}
+		s.set(u, c)
+	}
+	return s.color
+}
+
+// Returns a coloring of an undirected graph with the fewest colors possible, its chromatic number
+// Branch and bound over DSatur's node order: a branch stops as soon as it needs as many colors as the
+// best coloring found, and the search ends early once that matches the size of a clique
+// The search is exponential in the worst case, so it suits graphs of up to roughly a hundred nodes
+// If ctx ends first the best coloring so far is returned with an error wrapping ErrSearchInterrupted
+// Returns an error for a directed graph or a self loop
+// Examples
+// colors, err := g.ExactColoring(ctx)
+// fmt.Println(slices.Max(helpers.MapValuesToSlice(colors)) + 1) // Output: 2
+func (g *Graph[K, V]) ExactColoring(ctx context.Context) (map[K]int, error) {
+	keys, adjacency, err := g.coloringAdjacency()
+	if err != nil {
+		return nil, err
+	}
+	n := len(keys)
+	best := dsatur(adjacency)
+	bestCount := 0
+	for _, c := range best {
+		bestCount = max(bestCount, c+1)
+	}
+	lower := greedyCliqueSize(adjacency)
+
+	s := newSaturation(adjacency)
+	steps := 0
+	var interrupted error
+	// used is the number of colors in the partial coloring of colored nodes
+	var search func(colored, used int)
+	search = func(colored, used int) {
+		if bestCount <= lower || interrupted != nil {
+			return
+		}
+		if steps%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				interrupted = fmt.Errorf("%w: %w", ErrSearchInterrupted, err)
+				return
+			}
+		}
+		steps++
+		if colored == n {
+			copy(best, s.color)
+			bestCount = used
+			return
+		}
+		u := s.next()
+		// A new color is only worth trying while it stays below the best count
+		for c := 0; c <= used && c < bestCount-1; c++ {
+			if s.counts[u][c] > 0 {
+				continue
+			}
+			s.set(u, c)
+			search(colored+1, max(used, c+1))
+			s.unset(u)
+		}
+	}
+	search(0, 0)
+	return colorMap(keys, best), interrupted
+}
+
+// Returns the size of a clique grown greedily from each node, a lower bound on the colors needed
+func greedyCliqueSize(adjacency [][]int) int {
+	return len(greedyClique(adjacency))
+}
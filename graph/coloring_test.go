@@ -0,0 +1,198 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// Returns an undirected graph over nodes 0..n-1 with the given edges
+func newUndirectedGraph(n int, edges [][2]int) *Graph[int, int] {
+	g := New[int, int]("undirected", false)
+	for i := 0; i < n; i++ {
+		g.AddNode(i, i)
+	}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	return g
+}
+
+// Returns the cycle 0..n-1
+func cycleEdges(n int) [][2]int {
+	edges := make([][2]int, 0, n)
+	for i := 0; i < n; i++ {
+		edges = append(edges, [2]int{i, (i + 1) % n})
+	}
+	return edges
+}
+
+// The Grötzsch graph has no triangles but needs 4 colors
+func newGrotzschGraph() *Graph[int, int] {
+	edges := cycleEdges(5)
+	for i := 0; i < 5; i++ {
+		edges = append(edges, [2]int{i + 5, (i + 1) % 5}, [2]int{i + 5, (i + 4) % 5}, [2]int{i + 5, 10})
+	}
+	return newUndirectedGraph(11, edges)
+}
+
+// Returns the number of colors used
+func colorCount(colors map[int]int) int {
+	count := 0
+	for _, c := range colors {
+		count = max(count, c+1)
+	}
+	return count
+}
+
+// Returns true if the graph can be colored with k colors, trying every assignment
+func bruteForceColorable(g *Graph[int, int], color []int, next, k int) bool {
+	if next == len(color) {
+		return true
+	}
+	for c := 0; c < k; c++ {
+		free := true
+		for v := range g.Edges[next] {
+			if v < next && color[v] == c {
+				free = false
+				break
+			}
+		}
+		if free {
+			color[next] = c
+			if bruteForceColorable(g, color, next+1, k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestIsValidColoring(t *testing.T) {
+	g := newUndirectedGraph(3, [][2]int{{0, 1}, {1, 2}})
+	if !g.IsValidColoring(map[int]int{0: 0, 1: 1, 2: 0}) {
+		t.Error("Expected alternating colors on a path to be valid")
+	}
+	if g.IsValidColoring(map[int]int{0: 0, 1: 0, 2: 1}) {
+		t.Error("Expected neighbors sharing a color to be invalid")
+	}
+	if g.IsValidColoring(map[int]int{0: 0, 1: 1}) {
+		t.Error("Expected a missing node to be invalid")
+	}
+}
+
+func TestGreedyColoring(t *testing.T) {
+	rng := rand.New(rand.NewSource(12))
+	g := randomGraph{n: 60, p: 0.15}.build(rng)
+	for _, strategy := range []ColoringStrategy{LargestFirst, SmallestLast, ConnectedSequential} {
+		colors, err := g.GreedyColoring(strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g.IsValidColoring(colors) {
+			t.Errorf("Strategy %d gave an invalid coloring", strategy)
+		}
+	}
+
+	// Trees need two colors, which BFS order and smallest last both find
+	tree := newUndirectedGraph(7, [][2]int{{0, 1}, {0, 2}, {1, 3}, {1, 4}, {2, 5}, {2, 6}})
+	for _, strategy := range []ColoringStrategy{SmallestLast, ConnectedSequential} {
+		colors, _ := tree.GreedyColoring(strategy)
+		if n := colorCount(colors); n != 2 || !tree.IsValidColoring(colors) {
+			t.Errorf("Strategy %d colored a tree with %d colors, expected 2", strategy, n)
+		}
+	}
+	if _, err := tree.GreedyColoring(ColoringStrategy(9)); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
+
+func TestDSatur(t *testing.T) {
+	wheel := cycleEdges(5)
+	for i := 0; i < 5; i++ {
+		wheel = append(wheel, [2]int{i, 5})
+	}
+	cases := []struct {
+		name string
+		g    *Graph[int, int]
+		want int
+	}{
+		{"even cycle", newUndirectedGraph(8, cycleEdges(8)), 2},
+		{"odd cycle", newUndirectedGraph(7, cycleEdges(7)), 3},
+		{"odd wheel", newUndirectedGraph(6, wheel), 4},
+	}
+	for _, c := range cases {
+		colors, err := c.g.DSatur()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := colorCount(colors); n != c.want || !c.g.IsValidColoring(colors) {
+			t.Errorf("DSatur colored the %s with %d colors, expected %d", c.name, n, c.want)
+		}
+	}
+}
+
+func TestExactColoring(t *testing.T) {
+	petersen := cycleEdges(5)
+	for i := 0; i < 5; i++ {
+		petersen = append(petersen, [2]int{i, i + 5}, [2]int{i + 5, (i+2)%5 + 5})
+	}
+	cases := []struct {
+		name string
+		g    *Graph[int, int]
+		want int
+	}{
+		{"Petersen graph", newUndirectedGraph(10, petersen), 3},
+		{"Grötzsch graph", newGrotzschGraph(), 4},
+		{"empty graph", newUndirectedGraph(3, nil), 1},
+	}
+	for _, c := range cases {
+		colors, err := c.g.ExactColoring(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := colorCount(colors); n != c.want || !c.g.IsValidColoring(colors) {
+			t.Errorf("ExactColoring colored the %s with %d colors, expected %d", c.name, n, c.want)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(21))
+	for round := 0; round < 20; round++ {
+		g := randomGraph{n: 9, p: 0.45}.build(rng)
+		colors, err := g.ExactColoring(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := colorCount(colors)
+		if !g.IsValidColoring(colors) {
+			t.Fatalf("Invalid coloring %v", colors)
+		}
+		if bruteForceColorable(g, make([]int, 9), 0, n-1) {
+			t.Errorf("ExactColoring used %d colors but %d are enough", n, n-1)
+		}
+	}
+}
+
+func TestExactColoringInterrupted(t *testing.T) {
+	g := newGrotzschGraph()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	colors, err := g.ExactColoring(ctx)
+	if !errors.Is(err, ErrSearchInterrupted) {
+		t.Fatalf("Expected an interruption, got %v", err)
+	}
+	if !g.IsValidColoring(colors) {
+		t.Error("Expected the interrupted search to still return a valid coloring")
+	}
+}
+
+func TestColoringErrors(t *testing.T) {
+	if _, err := New[int, int]("directed", true).DSatur(); err == nil {
+		t.Error("Expected an error for a directed graph")
+	}
+	g := newUndirectedGraph(2, [][2]int{{0, 0}})
+	if _, err := g.GreedyColoring(LargestFirst); err == nil {
+		t.Error("Expected an error for a self loop")
+	}
+}
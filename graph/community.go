@@ -0,0 +1,340 @@
+package graph
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// Partition splits the nodes of a graph into communities
+// Communities maps every node to its community, numbered from 0
+// Modularity scores the split, see Graph.Modularity
+type Partition[K comparable] struct {
+	Communities map[K]int
+	Modularity  float64
+}
+
+// Undirected weighted graph over node indexes used by the community searches
+// adjacency[i] holds both directions of every edge, a self loop appears once
+// degree[i] counts a self loop twice so degrees always add up to twice the total weight
+type communityGraph struct {
+	adjacency [][]communityArc
+	degree    []float64
+	total     float64
+}
+
+type communityArc struct {
+	to     int
+	weight float64
+}
+
+// Returns the node keys sorted by their printed form
+// Map order changes from run to run, a fixed order makes seeded runs repeatable
+func (g *Graph[K, V]) sortedKeys() []K {
+	keys := make([]K, 0, len(g.Nodes))
+	printed := make(map[K]string, len(g.Nodes))
+	for k := range g.Nodes {
+		keys = append(keys, k)
+		printed[k] = fmt.Sprint(k)
+	}
+	slices.SortFunc(keys, func(a, b K) int { return cmp.Compare(printed[a], printed[b]) })
+	return keys
+}
+
+// Returns rng, or a randomly seeded generator when it is nil
+func orRandom(rng *rand.Rand) *rand.Rand {
+	if rng == nil {
+		return rand.New(rand.NewSource(rand.Int63()))
+	}
+	return rng
+}
+
+// Builds the index graph for community detection from an undirected graph with non-negative weights
+func (g *Graph[K, V]) newCommunityGraph(keys []K) (*communityGraph, error) {
+	if g.IsDirected {
+		return nil, errors.New("community detection needs an undirected graph")
+	}
+	index := make(map[K]int, len(keys))
+	for i, k := range keys {
+		index[k] = i
+	}
+	cg := &communityGraph{
+		adjacency: make([][]communityArc, len(keys)),
+		degree:    make([]float64, len(keys)),
+	}
+	for i, k := range keys {
+		for v, weight := range g.Edges[k] {
+			if weight < 0 {
+				return nil, errors.New("community detection needs non-negative edge weights")
+			}
+			j := index[v]
+			cg.adjacency[i] = append(cg.adjacency[i], communityArc{to: j, weight: weight})
+			cg.degree[i] += weight
+			if i == j {
+				cg.degree[i] += weight
+			}
+		}
+		slices.SortFunc(cg.adjacency[i], func(a, b communityArc) int { return cmp.Compare(a.to, b.to) })
+		cg.total += cg.degree[i]
+	}
+	cg.total /= 2
+	return cg, nil
+}
+
+// Returns the modularity of community over the index graph
+// Q is the sum over communities of the fraction of weight inside them minus the fraction expected at random
+func (cg *communityGraph) modularity(community []int) float64 {
+	if cg.total == 0 {
+		return 0
+	}
+	inside := make(map[int]float64)
+	degree := make(map[int]float64)
+	for i, arcs := range cg.adjacency {
+		degree[community[i]] += cg.degree[i]
+		for _, arc := range arcs {
+			if community[arc.to] != community[i] {
+				continue
+			}
+			if arc.to == i {
+				inside[community[i]] += arc.weight
+			} else {
+				inside[community[i]] += arc.weight / 2
+			}
+		}
+	}
+	q := 0.0
+	for c, d := range degree {
+		share := d / (2 * cg.total)
+		q += inside[c]/cg.total - share*share
+	}
+	return q
+}
+
+// Renumbers the communities from 0 in order of their first node and returns how many there are
+func renumberCommunities(community []int) int {
+	ids := make(map[int]int)
+	for i, c := range community {
+		id, exists := ids[c]
+		if !exists {
+			id = len(ids)
+			ids[c] = id
+		}
+		community[i] = id
+	}
+	return len(ids)
+}
+
+// Maps each key to the community at its index
+func (g *Graph[K, V]) partition(keys []K, cg *communityGraph, community []int) *Partition[K] {
+	p := &Partition[K]{Communities: make(map[K]int, len(keys)), Modularity: cg.modularity(community)}
+	for i, k := range keys {
+		p.Communities[k] = community[i]
+	}
+	return p
+}
+
+// Returns the modularity of splitting the graph into the given communities
+// Modularity is the fraction of edge weight inside communities minus the fraction expected if edges were
+// placed at random with the same degrees, it ranges from -1/2 to 1 and higher means denser communities
+// Returns an error for a directed graph or when a node has no community
+// Examples
+// q, err := g.Modularity(map[string]int{"A": 0, "B": 0, "C": 1, "D": 1})
+func (g *Graph[K, V]) Modularity(communities map[K]int) (float64, error) {
+	keys := g.sortedKeys()
+	cg, err := g.newCommunityGraph(keys)
+	if err != nil {
+		return 0, err
+	}
+	community := make([]int, len(keys))
+	for i, k := range keys {
+		c, exists := communities[k]
+		if !exists {
+			return 0, fmt.Errorf("node %v has no community", k)
+		}
+		community[i] = c
+	}
+	return cg.modularity(community), nil
+}
+
+// Returns a partition of an undirected graph found with the Louvain method
+// Each level moves single nodes to the neighboring community that raises modularity the most until
+// nothing improves, then merges every community into one node and repeats on the smaller graph
+// Edge weights are connection strengths and must be non-negative
+// rng decides the order nodes are visited in, so a fixed seed gives the same partition every run, nil picks a random seed
+// Returns an error for a directed graph or a negative weight
+// Examples
+// p, err := g.Louvain(rand.New(rand.NewSource(1)))
+// fmt.Println(p.Communities["A"] == p.Communities["B"], p.Modularity)
+func (g *Graph[K, V]) Louvain(rng *rand.Rand) (*Partition[K], error) {
+	keys := g.sortedKeys()
+	cg, err := g.newCommunityGraph(keys)
+	if err != nil {
+		return nil, err
+	}
+	// community[i] is the community of original node i, level holds the merged graph being worked on
+	community := make([]int, len(keys))
+	for i := range community {
+		community[i] = i
+	}
+	rng = orRandom(rng)
+	level := cg
+	for {
+		moved, levelCommunity := level.moveNodes(rng)
+		count := renumberCommunities(levelCommunity)
+		for i, c := range community {
+			community[i] = levelCommunity[c]
+		}
+		if !moved {
+			break
+		}
+		level = level.merge(levelCommunity, count)
+	}
+	renumberCommunities(community)
+	return g.partition(keys, cg, community), nil
+}
+
+// Runs the Louvain local moving phase and returns whether any node changed community
+func (cg *communityGraph) moveNodes(rng *rand.Rand) (bool, []int) {
+	n := len(cg.adjacency)
+	community := make([]int, n)
+	// tot[c] is the total degree of the nodes in community c
+	tot := make([]float64, n)
+	for i := range community {
+		community[i] = i
+		tot[i] = cg.degree[i]
+	}
+	if cg.total == 0 {
+		return false, community
+	}
+	order := rng.Perm(n)
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for _, i := range order {
+			// Weight from i into each neighboring community, in ascending community order for repeatable ties
+			links := make(map[int]float64)
+			for _, arc := range cg.adjacency[i] {
+				if arc.to != i {
+					links[community[arc.to]] += arc.weight
+				}
+			}
+			own := community[i]
+			tot[own] -= cg.degree[i]
+			// Gain of joining c is links[c] - tot[c]*degree/2m, up to a constant factor
+			best := own
+			bestGain := links[own] - tot[own]*cg.degree[i]/(2*cg.total)
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			slices.Sort(candidates)
+			for _, c := range candidates {
+				if gain := links[c] - tot[c]*cg.degree[i]/(2*cg.total); gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+			tot[best] += cg.degree[i]
+			if best != own {
+				community[i] = best
+				improved = true
+				moved = true
+			}
+		}
+	}
+	return moved, community
+}
+
+// Returns the graph with one node per community, edges inside a community become a self loop
+func (cg *communityGraph) merge(community []int, count int) *communityGraph {
+	weights := make([]map[int]float64, count)
+	for c := range weights {
+		weights[c] = make(map[int]float64)
+	}
+	for i, arcs := range cg.adjacency {
+		for _, arc := range arcs {
+			ci, cj := community[i], community[arc.to]
+			if ci == cj && i != arc.to {
+				// Both directions of an edge inside a community add up to one self loop
+				weights[ci][ci] += arc.weight / 2
+			} else {
+				weights[ci][cj] += arc.weight
+			}
+		}
+	}
+	merged := &communityGraph{
+		adjacency: make([][]communityArc, count),
+		degree:    make([]float64, count),
+		total:     cg.total,
+	}
+	for i := range cg.adjacency {
+		merged.degree[community[i]] += cg.degree[i]
+	}
+	for c, neighbors := range weights {
+		for d, weight := range neighbors {
+			merged.adjacency[c] = append(merged.adjacency[c], communityArc{to: d, weight: weight})
+		}
+		slices.SortFunc(merged.adjacency[c], func(a, b communityArc) int { return cmp.Compare(a.to, b.to) })
+	}
+	return merged
+}
+
+// Returns a partition of an undirected graph found with asynchronous label propagation
+// Every node starts with its own label, then nodes in random order take the label with the most
+// edge weight among their neighbors until every node already holds one of its best labels
+// Ties are broken at random, a node keeps its label if it is among the best
+// Much faster than Louvain on large graphs, though the partition usually has lower modularity
+// rng decides the visiting order and the ties, so a fixed seed gives the same partition every run, nil picks a random seed
+// Returns an error for a directed graph or a negative weight
+// Examples
+// p, err := g.LabelPropagation(rand.New(rand.NewSource(1)))
+func (g *Graph[K, V]) LabelPropagation(rng *rand.Rand) (*Partition[K], error) {
+	keys := g.sortedKeys()
+	cg, err := g.newCommunityGraph(keys)
+	if err != nil {
+		return nil, err
+	}
+	rng = orRandom(rng)
+	label := make([]int, len(keys))
+	for i := range label {
+		label[i] = i
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	for changed := true; changed; {
+		changed = false
+		rng.Shuffle(len(order), func(a, b int) { order[a], order[b] = order[b], order[a] })
+		for _, i := range order {
+			weights := make(map[int]float64)
+			for _, arc := range cg.adjacency[i] {
+				if arc.to != i {
+					weights[label[arc.to]] += arc.weight
+				}
+			}
+			if len(weights) == 0 {
+				continue
+			}
+			heaviest := 0.0
+			for _, w := range weights {
+				heaviest = max(heaviest, w)
+			}
+			best := make([]int, 0)
+			for l, w := range weights {
+				if w == heaviest {
+					best = append(best, l)
+				}
+			}
+			if slices.Contains(best, label[i]) {
+				continue
+			}
+			slices.Sort(best)
+			label[i] = best[rng.Intn(len(best))]
+			changed = true
+		}
+	}
+	renumberCommunities(label)
+	return g.partition(keys, cg, label), nil
+}
@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"maps"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns cliques of size nodes each, joined into a ring by single edges
+// Node i belongs to clique i / size
+func newRingOfCliques(cliques, size int) *Graph[int, int] {
+	g := New[int, int]("cliques", false)
+	for i := 0; i < cliques*size; i++ {
+		g.AddNode(i, i)
+	}
+	for c := 0; c < cliques; c++ {
+		for a := 0; a < size; a++ {
+			for b := a + 1; b < size; b++ {
+				g.AddEdge(c*size+a, c*size+b, 1)
+			}
+		}
+		g.AddEdge(c*size, ((c+1)%cliques)*size+1, 1)
+	}
+	return g
+}
+
+// Checks that two nodes share a community exactly when they share a clique
+func checkCliqueCommunities(t *testing.T, name string, p *Partition[int], size int) {
+	t.Helper()
+	for u, cu := range p.Communities {
+		for v, cv := range p.Communities {
+			if (cu == cv) != (u/size == v/size) {
+				t.Fatalf("%s put %d in community %d and %d in %d", name, u, cu, v, cv)
+			}
+		}
+	}
+}
+
+func TestModularity(t *testing.T) {
+	g := newRingOfCliques(2, 4)
+	communities := make(map[int]int)
+	for i := 0; i < 8; i++ {
+		communities[i] = i / 4
+	}
+	// 14 edges, each clique has 6 inside and a degree total of 14
+	q, err := g.Modularity(communities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 12.0/14 - 0.5; math.Abs(q-want) > 1e-9 {
+		t.Errorf("Modularity %v, expected %v", q, want)
+	}
+	for i := range communities {
+		communities[i] = 0
+	}
+	if q, _ := g.Modularity(communities); math.Abs(q) > 1e-9 {
+		t.Errorf("Modularity of a single community %v, expected 0", q)
+	}
+	delete(communities, 3)
+	if _, err := g.Modularity(communities); err == nil {
+		t.Error("Expected an error for a node without a community")
+	}
+}
+
+func TestLouvain(t *testing.T) {
+	g := newRingOfCliques(6, 5)
+	p, err := g.Louvain(rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCliqueCommunities(t, "Louvain", p, 5)
+	if q, _ := g.Modularity(p.Communities); math.Abs(q-p.Modularity) > 1e-9 {
+		t.Errorf("Partition modularity %v, Modularity gives %v", p.Modularity, q)
+	}
+	for _, c := range p.Communities {
+		if c < 0 || c >= 6 {
+			t.Errorf("Community %d out of range", c)
+		}
+	}
+}
+
+func TestLouvainSeeded(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	g := randomGraph{n: 60, p: 0.08, weight: func(rng *rand.Rand) float64 {
+		return float64(1 + rng.Intn(5))
+	}}.build(rng)
+	first, err := g.Louvain(rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := g.Louvain(rand.New(rand.NewSource(2)))
+	if !maps.Equal(first.Communities, second.Communities) {
+		t.Error("Expected the same seed to give the same partition")
+	}
+	// Louvain only accepts moves that raise modularity, so it beats leaving every node alone
+	singletons := make(map[int]int)
+	for i := 0; i < 60; i++ {
+		singletons[i] = i
+	}
+	if q, _ := g.Modularity(singletons); first.Modularity <= q {
+		t.Errorf("Louvain modularity %v not above singletons %v", first.Modularity, q)
+	}
+}
+
+func TestLabelPropagation(t *testing.T) {
+	g := newRingOfCliques(4, 5)
+	first, err := g.LabelPropagation(rand.New(rand.NewSource(3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCliqueCommunities(t, "LabelPropagation", first, 5)
+	second, _ := g.LabelPropagation(rand.New(rand.NewSource(3)))
+	if !maps.Equal(first.Communities, second.Communities) {
+		t.Error("Expected the same seed to give the same partition")
+	}
+
+	g.AddNode(100, 100)
+	p, _ := g.LabelPropagation(nil)
+	for k, c := range p.Communities {
+		if k != 100 && c == p.Communities[100] {
+			t.Errorf("Isolated node shares community %d with %d", c, k)
+		}
+	}
+}
+
+func TestCommunityErrors(t *testing.T) {
+	g := New[int, int]("directed", true)
+	g.AddNode(0, 0)
+	if _, err := g.Louvain(nil); err == nil {
+		t.Error("Expected an error for a directed graph")
+	}
+	u := New[int, int]("negative", false)
+	u.AddNode(0, 0)
+	u.AddNode(1, 1)
+	u.AddEdge(0, 1, -1)
+	if _, err := u.LabelPropagation(nil); err == nil {
+		t.Error("Expected an error for a negative weight")
+	}
+}
@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"main.go/helpers"
+)
+
+// Returns the connected components of the graph, each as a slice of node keys
+// For a directed graph edge directions are ignored, which gives the weakly connected components
+// Examples
+// g := New[string, int]("MyGraph", false)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// fmt.Println(len(g.ConnectedComponents())) // Output: 2
+func (g *Graph[K, V]) ConnectedComponents() [][]K {
+	uf := helpers.NewUnionFind[K]()
+	for k := range g.Nodes {
+		uf.Add(k)
+	}
+	for u, edges := range g.Edges {
+		for v := range edges {
+			uf.Union(u, v)
+		}
+	}
+	return helpers.MapValuesToSlice(uf.Components())
+}
+
+// Returns true if every node can reach every other node ignoring edge directions
+func (g *Graph[K, V]) IsConnected() bool {
+	return len(g.ConnectedComponents()) <= 1
+}
+
+// One call on the explicit DFS stack, next is the index of the neighbor to visit next
+// Tarjan's and Kosaraju's SCCs, the DAG walk behind topological sorting and cycle detection, and the
+// articulation point and bridge search run on a slice of these rather than the call stack,
+// so a long path through a large graph can't overflow the goroutine stack
+type dfsFrame[K comparable] struct {
+	node      K
+	neighbors []K
+	next      int
+}
+
+func newDFSFrame[K comparable](k K, adjacency map[K]map[K]float64) dfsFrame[K] {
+	return dfsFrame[K]{node: k, neighbors: helpers.MapKeysToSlice(adjacency[k])}
+}
+
+// Returns the strongly connected components using Tarjan's algorithm
+// Within a component every node can reach every other node following edge directions
+// Components come out in reverse topological order: no component has an edge to one listed after it
+// For an undirected graph these are the connected components
+// Examples
+// g := New[string, int]("MyGraph", true)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "A", 1)
+// g.AddEdge("B", "C", 1)
+// fmt.Println(g.StronglyConnectedComponents()) // Output: [[C] [A B]]
+func (g *Graph[K, V]) StronglyConnectedComponents() [][]K {
+	index := make(map[K]int, len(g.Nodes))
+	low := make(map[K]int, len(g.Nodes))
+	onStack := make(map[K]bool)
+	stack := make([]K, 0)
+	components := make([][]K, 0)
+
+	visit := func(k K) {
+		index[k] = len(index)
+		low[k] = index[k]
+		stack = append(stack, k)
+		onStack[k] = true
+	}
+
+	for root := range g.Nodes {
+		if _, seen := index[root]; seen {
+			continue
+		}
+		visit(root)
+		calls := []dfsFrame[K]{newDFSFrame(root, g.Edges)}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(f.neighbors) {
+				w := f.neighbors[f.next]
+				f.next++
+				if _, seen := index[w]; !seen {
+					visit(w)
+					calls = append(calls, newDFSFrame(w, g.Edges))
+				} else if onStack[w] {
+					low[f.node] = min(low[f.node], index[w])
+				}
+				continue
+			}
+
+			node := f.node
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].node
+				low[parent] = min(low[parent], low[node])
+			}
+			if low[node] != index[node] {
+				continue
+			}
+			// node is the root of a component, pop it off the stack
+			component := make([]K, 0)
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == node {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// Returns the strongly connected components using Kosaraju's algorithm
+// A first DFS records finishing order and a second DFS over the reversed edges collects the components
+// Components come out in topological order, the opposite of StronglyConnectedComponents
+// Examples
+// fmt.Println(g.KosarajuSCC()) // Output: [[A B] [C]]
+func (g *Graph[K, V]) KosarajuSCC() [][]K {
+	visited := make(map[K]bool, len(g.Nodes))
+	finished := make([]K, 0, len(g.Nodes))
+	for root := range g.Nodes {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		calls := []dfsFrame[K]{newDFSFrame(root, g.Edges)}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(f.neighbors) {
+				w := f.neighbors[f.next]
+				f.next++
+				if !visited[w] {
+					visited[w] = true
+					calls = append(calls, newDFSFrame(w, g.Edges))
+				}
+				continue
+			}
+			finished = append(finished, f.node)
+			calls = calls[:len(calls)-1]
+		}
+	}
+
+	reverse := g.reverseEdges()
+	assigned := make(map[K]bool, len(g.Nodes))
+	components := make([][]K, 0)
+	for i := len(finished) - 1; i >= 0; i-- {
+		root := finished[i]
+		if assigned[root] {
+			continue
+		}
+		assigned[root] = true
+		component := make([]K, 0)
+		stack := []K{root}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			component = append(component, node)
+			for w := range reverse[node] {
+				if !assigned[w] {
+					assigned[w] = true
+					stack = append(stack, w)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// Returns the condensation of the graph: a DAG with one node per strongly connected component
+// Node keys are component numbers in topological order, so every edge goes from a lower to a higher number
+// Each node's value holds the keys of its component
+// Two components are joined by the cheapest edge between them
+// The returned map gives the component number of every node of g
+// Components whose node has no outgoing edges are dead ends that can't be left once entered
+// Examples
+// dag, componentOf := g.Condensation()
+// fmt.Println(dag.LengthNodes(), componentOf["A"] == componentOf["B"]) // Output: 2 true
+func (g *Graph[K, V]) Condensation() (*Graph[int, []K], map[K]int) {
+	components := g.StronglyConnectedComponents()
+	dag := New[int, []K](g.Name, true)
+	componentOf := make(map[K]int, len(g.Nodes))
+	// Tarjan lists components in reverse topological order
+	for i := range components {
+		number := len(components) - 1 - i
+		dag.AddNode(number, components[i])
+		for _, k := range components[i] {
+			componentOf[k] = number
+		}
+	}
+	for u, edges := range g.Edges {
+		for v, weight := range edges {
+			cu, cv := componentOf[u], componentOf[v]
+			if cu == cv {
+				continue
+			}
+			if dag.ContainsEdge(cu, cv) && dag.GetEdgeWeight(cu, cv) <= weight {
+				continue
+			}
+			dag.AddEdge(cu, cv, weight)
+		}
+	}
+	return dag, componentOf
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Sorts each component and the list of components so results can be compared
+func normalizeComponents(components [][]int) []string {
+	keys := make([]string, 0, len(components))
+	for _, c := range components {
+		c = slices.Clone(c)
+		slices.Sort(c)
+		keys = append(keys, fmt.Sprint(c))
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := New[int, int]("components", true)
+	for i := 0; i < 7; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(2, 1, 1)
+	g.AddEdge(3, 4, 1)
+	want := []string{"[0 1 2]", "[3 4]", "[5]", "[6]"}
+	if got := normalizeComponents(g.ConnectedComponents()); !slices.Equal(got, want) {
+		t.Errorf("ConnectedComponents %v, expected %v", got, want)
+	}
+	if g.IsConnected() {
+		t.Error("Expected graph to not be connected")
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := New[int, int]("scc", true)
+	for i := 0; i < 8; i++ {
+		g.AddNode(i, i)
+	}
+	// Cycles 0-1-2 and 3-4, with 5 a dead end, 6 on its own and 7 a self loop
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(2, 3, 4)
+	g.AddEdge(1, 3, 2)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 3, 1)
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(7, 7, 1)
+	want := []string{"[0 1 2]", "[3 4]", "[5]", "[6]", "[7]"}
+	if got := normalizeComponents(g.StronglyConnectedComponents()); !slices.Equal(got, want) {
+		t.Errorf("Tarjan %v, expected %v", got, want)
+	}
+	if got := normalizeComponents(g.KosarajuSCC()); !slices.Equal(got, want) {
+		t.Errorf("Kosaraju %v, expected %v", got, want)
+	}
+
+	dag, componentOf := g.Condensation()
+	if dag.LengthNodes() != 5 || dag.LengthEdges() != 2 {
+		t.Errorf("Condensation has %d nodes and %d edges, expected 5 and 2", dag.LengthNodes(), dag.LengthEdges())
+	}
+	if dag.GetEdgeWeight(componentOf[0], componentOf[3]) != 2 {
+		t.Errorf("Expected the cheapest edge between components, got %f", dag.GetEdgeWeight(componentOf[0], componentOf[3]))
+	}
+	for u, edges := range dag.Edges {
+		for v := range edges {
+			if u >= v {
+				t.Errorf("Condensation edge %d->%d is not in topological order", u, v)
+			}
+		}
+	}
+}
+
+func TestSCCLargeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(8))
+	g := randomGraph{n: 2000, p: 0.00065, directed: true}.build(rng)
+	// A long chain checks the DFS doesn't rely on recursion
+	for i := 0; i < 1999; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+	tarjan := normalizeComponents(g.StronglyConnectedComponents())
+	kosaraju := normalizeComponents(g.KosarajuSCC())
+	if !slices.Equal(tarjan, kosaraju) {
+		t.Errorf("Tarjan found %d components, Kosaraju %d", len(tarjan), len(kosaraju))
+	}
+}
@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var errUndirectedTopologicalSort = errors.New("topological sort needs a directed graph")
+
+// CycleError is returned when an operation needs a graph without cycles
+// Cycle lists the node keys in edge order, the last node has an edge back to the first
+type CycleError[K comparable] struct {
+	Cycle []K
+}
+
+func (e *CycleError[K]) Error() string {
+	return fmt.Sprintf("graph has a cycle: %v", e.Cycle)
+}
+
+// Returns a topological order of a directed graph using Kahn's algorithm
+// Every edge goes from a node earlier in the order to a node later in it
+// Nodes with no remaining incoming edges are taken first, so the order suits scheduling task dependencies
+// If the graph has a cycle, returns a *CycleError naming one
+// Examples
+// g := New[string, int]("tasks", true)
+// g.AddNode("fetch", 1)
+// g.AddNode("build", 2)
+// g.AddNode("test", 3)
+// g.AddEdge("fetch", "build", 1)
+// g.AddEdge("build", "test", 1)
+// order, err := g.TopologicalSort()
+// fmt.Println(order) // Output: [fetch build test]
+func (g *Graph[K, V]) TopologicalSort() ([]K, error) {
+	if !g.IsDirected {
+		return nil, errUndirectedTopologicalSort
+	}
+	inDegree := make(map[K]int, len(g.Nodes))
+	for k := range g.Nodes {
+		inDegree[k] = 0
+	}
+	for _, edges := range g.Edges {
+		for v := range edges {
+			inDegree[v]++
+		}
+	}
+	queue := make([]K, 0)
+	for k, degree := range inDegree {
+		if degree == 0 {
+			queue = append(queue, k)
+		}
+	}
+
+	order := make([]K, 0, len(g.Nodes))
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		order = append(order, node)
+		for v := range g.Edges[node] {
+			inDegree[v]--
+			if inDegree[v] == 0 {
+				queue = append(queue, v)
+			}
+		}
+	}
+	if len(order) < len(g.Nodes) {
+		cycle, _ := g.FindCycle()
+		return nil, &CycleError[K]{Cycle: cycle}
+	}
+	return order, nil
+}
+
+// Returns a topological order of a directed graph using depth-first search
+// The order is the reverse of the order in which the DFS finishes nodes
+// If the graph has a cycle, returns a *CycleError naming one
+// Examples
+// order, err := g.TopologicalSortDFS()
+// fmt.Println(order) // Output: [fetch build test]
+func (g *Graph[K, V]) TopologicalSortDFS() ([]K, error) {
+	if !g.IsDirected {
+		return nil, errUndirectedTopologicalSort
+	}
+	finished := make([]K, 0, len(g.Nodes))
+	cycle := g.walkDFS(func(k K) { finished = append(finished, k) })
+	if cycle != nil {
+		return nil, &CycleError[K]{Cycle: cycle}
+	}
+	slices.Reverse(finished)
+	return finished, nil
+}
+
+// Returns true if the graph has a cycle
+// In an undirected graph going back along the same edge does not count as a cycle, self loops do
+func (g *Graph[K, V]) HasCycle() bool {
+	_, found := g.FindCycle()
+	return found
+}
+
+// Returns the nodes of a cycle in edge order, or false if the graph has none
+// The last node has an edge back to the first
+// Examples
+// g.AddEdge("test", "fetch", 1)
+// cycle, found := g.FindCycle()
+// fmt.Println(cycle, found) // Output: [fetch build test] true
+func (g *Graph[K, V]) FindCycle() ([]K, bool) {
+	cycle := g.walkDFS(nil)
+	return cycle, cycle != nil
+}
+
+// Runs an iterative DFS over the whole graph, calling finish on each node once all its descendants are done
+// Stops at the first cycle and returns it, or returns nil if there is none
+func (g *Graph[K, V]) walkDFS(finish func(k K)) []K {
+	// A node is on the current DFS path while it is in active
+	active := make(map[K]bool)
+	done := make(map[K]bool, len(g.Nodes))
+	for root := range g.Nodes {
+		if done[root] {
+			continue
+		}
+		active[root] = true
+		calls := []dfsFrame[K]{newDFSFrame(root, g.Edges)}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(f.neighbors) {
+				w := f.neighbors[f.next]
+				f.next++
+				if done[w] {
+					continue
+				}
+				if active[w] {
+					// In an undirected graph the edge back to the DFS parent is the same edge
+					if !g.IsDirected && w != f.node && len(calls) > 1 && calls[len(calls)-2].node == w {
+						continue
+					}
+					return cycleOnStack(calls, w)
+				}
+				active[w] = true
+				calls = append(calls, newDFSFrame(w, g.Edges))
+				continue
+			}
+			delete(active, f.node)
+			done[f.node] = true
+			if finish != nil {
+				finish(f.node)
+			}
+			calls = calls[:len(calls)-1]
+		}
+	}
+	return nil
+}
+
+// Returns the part of the DFS path from w to the current node
+func cycleOnStack[K comparable](calls []dfsFrame[K], w K) []K {
+	cycle := make([]K, 0)
+	for i := len(calls) - 1; i >= 0; i-- {
+		cycle = append(cycle, calls[i].node)
+		if calls[i].node == w {
+			break
+		}
+	}
+	slices.Reverse(cycle)
+	return cycle
+}
+
+// Returns the longest weighted path in a directed acyclic graph, also known as the critical path
+// With edge weights as task durations its cost is the shortest time to finish every task
+// The path may start and end at any node, and a single node is a path of cost 0
+// If the graph has a cycle, returns a *CycleError naming one
+// Examples
+// g.AddEdge("fetch", "build", 3)
+// g.AddEdge("build", "test", 5)
+// g.AddEdge("fetch", "test", 2)
+// critical, err := g.LongestPath()
+// fmt.Println(critical) // Output: {[fetch build test] 8}
+func (g *Graph[K, V]) LongestPath() (WeightedPath[K], error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return WeightedPath[K]{}, err
+	}
+	if len(order) == 0 {
+		return WeightedPath[K]{Path: []K{}}, nil
+	}
+	dist := make(map[K]float64, len(order))
+	parents := make(map[K]K)
+	best := order[0]
+	for _, u := range order {
+		if dist[u] > dist[best] {
+			best = u
+		}
+		// Every node is final once reached in topological order, starting fresh costs 0
+		for v, weight := range g.Edges[u] {
+			if newDist := dist[u] + weight; newDist > dist[v] {
+				dist[v] = newDist
+				parents[v] = u
+			}
+		}
+	}
+	start := best
+	for {
+		parent, exists := parents[start]
+		if !exists {
+			break
+		}
+		start = parent
+	}
+	return WeightedPath[K]{Path: constructPath(parents, start, best), Cost: dist[best]}, nil
+}
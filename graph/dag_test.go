@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func newTaskGraph() *Graph[string, int] {
+	g := New[string, int]("tasks", true)
+	for _, k := range []string{"fetch", "configure", "build", "lint", "test", "release"} {
+		g.AddNode(k, 0)
+	}
+	g.AddEdge("fetch", "configure", 1)
+	g.AddEdge("fetch", "lint", 1)
+	g.AddEdge("configure", "build", 4)
+	g.AddEdge("build", "test", 3)
+	g.AddEdge("lint", "release", 2)
+	g.AddEdge("test", "release", 1)
+	return g
+}
+
+func checkTopologicalOrder(t *testing.T, name string, g *Graph[string, int], order []string) {
+	position := make(map[string]int)
+	for i, k := range order {
+		position[k] = i
+	}
+	if len(position) != g.LengthNodes() {
+		t.Errorf("%s order %v does not hold every node once", name, order)
+	}
+	for u, edges := range g.Edges {
+		for v := range edges {
+			if position[u] >= position[v] {
+				t.Errorf("%s order %v puts %s after %s", name, order, u, v)
+			}
+		}
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := newTaskGraph()
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort failed: %v", err)
+	}
+	checkTopologicalOrder(t, "Kahn", g, order)
+	order, err = g.TopologicalSortDFS()
+	if err != nil {
+		t.Fatalf("TopologicalSortDFS failed: %v", err)
+	}
+	checkTopologicalOrder(t, "DFS", g, order)
+	if g.HasCycle() {
+		t.Error("Expected no cycle")
+	}
+
+	critical, err := g.LongestPath()
+	if err != nil {
+		t.Fatalf("LongestPath failed: %v", err)
+	}
+	want := []string{"fetch", "configure", "build", "test", "release"}
+	if !reflect.DeepEqual(critical.Path, want) || critical.Cost != 9 {
+		t.Errorf("LongestPath %v, expected %v at cost 9", critical, want)
+	}
+
+	g.AddEdge("release", "configure", 1)
+	for name, sort := range map[string]func() ([]string, error){"Kahn": g.TopologicalSort, "DFS": g.TopologicalSortDFS} {
+		_, err := sort()
+		var cycleErr *CycleError[string]
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("%s: expected CycleError, got %v", name, err)
+		}
+		for i, k := range cycleErr.Cycle {
+			if !g.ContainsEdge(k, cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)]) {
+				t.Errorf("%s: cycle %v is not a cycle of the graph", name, cycleErr.Cycle)
+			}
+		}
+	}
+	if _, err := g.LongestPath(); err == nil {
+		t.Error("Expected LongestPath to fail on a cyclic graph")
+	}
+}
+
+func TestFindCycleUndirected(t *testing.T) {
+	g := New[int, int]("tree", false)
+	for i := 0; i < 6; i++ {
+		g.AddNode(i, i)
+	}
+	for i := 1; i < 6; i++ {
+		g.AddEdge((i-1)/2, i, 1)
+	}
+	if cycle, found := g.FindCycle(); found {
+		t.Errorf("Expected a tree to have no cycle, found %v", cycle)
+	}
+	g.AddEdge(3, 4, 1)
+	cycle, found := g.FindCycle()
+	if !found || len(cycle) != 3 {
+		t.Errorf("Expected the cycle 1-3-4, got %v", cycle)
+	}
+	if _, err := g.TopologicalSort(); err == nil {
+		t.Error("Expected TopologicalSort to reject an undirected graph")
+	}
+}
+
+func TestFindCycleRandomDAG(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	g := randomGraph{n: 500, p: 0.008, directed: true, acyclic: true}.build(rng)
+	if cycle, found := g.FindCycle(); found {
+		t.Fatalf("Found cycle %v in a DAG", cycle)
+	}
+	g.AddEdge(499, 0, 1)
+	g.AddEdge(0, 499, 1)
+	if cycle, found := g.FindCycle(); !found || len(cycle) < 2 {
+		t.Errorf("Expected a cycle, got %v", cycle)
+	}
+}
@@ -0,0 +1,401 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+var errNoEulerianPath = errors.New("graph has no Eulerian path")
+
+// Multigraph of the edges an Euler walk has to cover, the same pair of nodes may be joined many times
+// adjacency[k] lists the ids of the edges leaving k, in an undirected graph an edge is listed at both ends
+// and a self loop twice at its node
+type eulerGraph[K comparable] struct {
+	directed  bool
+	edges     []Edge[K]
+	adjacency map[K][]int
+}
+
+// Returns the edges of g as an Euler multigraph, an undirected edge or self loop is added once
+func (g *Graph[K, V]) newEulerGraph() *eulerGraph[K] {
+	eg := &eulerGraph[K]{directed: g.IsDirected, edges: make([]Edge[K], 0), adjacency: make(map[K][]int)}
+	if g.IsDirected {
+		for u, neighbors := range g.Edges {
+			for v, weight := range neighbors {
+				eg.addEdge(Edge[K]{From: u, To: v, Weight: weight})
+			}
+		}
+		return eg
+	}
+	for _, e := range g.undirectedEdges() {
+		eg.addEdge(e)
+	}
+	for u, neighbors := range g.Edges {
+		if weight, loop := neighbors[u]; loop {
+			eg.addEdge(Edge[K]{From: u, To: u, Weight: weight})
+		}
+	}
+	return eg
+}
+
+func (eg *eulerGraph[K]) addEdge(e Edge[K]) {
+	id := len(eg.edges)
+	eg.edges = append(eg.edges, e)
+	eg.adjacency[e.From] = append(eg.adjacency[e.From], id)
+	if !eg.directed {
+		eg.adjacency[e.To] = append(eg.adjacency[e.To], id)
+	}
+}
+
+// Returns the node an Euler path has to start from and whether the path closes into a circuit
+// Returns errNoEulerianPath if the degrees rule out an Euler path
+// Only degrees are checked, walk notices when the edges are not connected
+func (eg *eulerGraph[K]) start() (K, bool, error) {
+	var start K
+	if len(eg.edges) == 0 {
+		return start, false, errors.New("graph has no edges")
+	}
+	start = eg.edges[0].From
+	if !eg.directed {
+		odd := make([]K, 0)
+		for k, ids := range eg.adjacency {
+			if len(ids)%2 == 1 {
+				odd = append(odd, k)
+			}
+		}
+		switch len(odd) {
+		case 0:
+			return start, true, nil
+		case 2:
+			return odd[0], false, nil
+		}
+		return start, false, errNoEulerianPath
+	}
+
+	// balance is out degree minus in degree
+	balance := make(map[K]int)
+	for _, e := range eg.edges {
+		balance[e.From]++
+		balance[e.To]--
+	}
+	starts, ends := 0, 0
+	for k, b := range balance {
+		switch b {
+		case 0:
+		case 1:
+			starts++
+			start = k
+		case -1:
+			ends++
+		default:
+			return start, false, errNoEulerianPath
+		}
+	}
+	if starts == 0 && ends == 0 {
+		return start, true, nil
+	}
+	if starts == 1 && ends == 1 {
+		return start, false, nil
+	}
+	return start, false, errNoEulerianPath
+}
+
+// Returns a walk from start using every edge exactly once with Hierholzer's algorithm
+// The degrees must already allow it, errNoEulerianPath is returned if some edges can't be reached
+// The stack holds the trail being followed, a node leaves it once all its edges are used,
+// which closes any detour and yields the walk back to front
+func (eg *eulerGraph[K]) walk(start K) ([]K, error) {
+	used := make([]bool, len(eg.edges))
+	// next[k] is the first entry of adjacency[k] that may still be unused
+	next := make(map[K]int)
+	stack := []K{start}
+	walk := make([]K, 0, len(eg.edges)+1)
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		ids := eg.adjacency[u]
+		for next[u] < len(ids) && used[ids[next[u]]] {
+			next[u]++
+		}
+		if next[u] == len(ids) {
+			// Dead end, u is the next node of the walk counting back from the end
+			walk = append(walk, u)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		id := ids[next[u]]
+		used[id] = true
+		e := eg.edges[id]
+		if e.From == u {
+			stack = append(stack, e.To)
+		} else {
+			stack = append(stack, e.From)
+		}
+	}
+	if len(walk) != len(eg.edges)+1 {
+		return nil, errNoEulerianPath
+	}
+	slices.Reverse(walk)
+	return walk, nil
+}
+
+// Returns the total weight of every edge in the multigraph
+func (eg *eulerGraph[K]) cost() float64 {
+	total := 0.0
+	for _, e := range eg.edges {
+		total += e.Weight
+	}
+	return total
+}
+
+// Returns true if the graph has an Eulerian circuit, a closed walk that uses every edge exactly once
+// Holds when the nodes with edges are connected and every node has even degree,
+// or in a directed graph as many incoming as outgoing edges
+func (g *Graph[K, V]) HasEulerianCircuit() bool {
+	_, err := g.EulerianCircuit()
+	return err == nil
+}
+
+// Returns true if the graph has an Eulerian path, a walk that uses every edge exactly once
+// Holds when the nodes with edges are connected and at most two nodes have odd degree,
+// or in a directed graph one node has an extra outgoing edge and another an extra incoming edge
+func (g *Graph[K, V]) HasEulerianPath() bool {
+	_, err := g.EulerianPath()
+	return err == nil
+}
+
+// Returns an Eulerian circuit built with Hierholzer's algorithm
+// The walk lists the nodes in order and ends where it started, following every edge exactly once
+// Self loops are walked too, isolated nodes are ignored
+// Returns an error if the graph has no edges or no Eulerian circuit
+// Examples
+// g := New[string, int]("triangle", false)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 1)
+// g.AddEdge("C", "A", 1)
+// circuit, err := g.EulerianCircuit()
+// fmt.Println(len(circuit)) // Output: 4
+func (g *Graph[K, V]) EulerianCircuit() ([]K, error) {
+	eg := g.newEulerGraph()
+	start, circuit, err := eg.start()
+	if err != nil {
+		return nil, err
+	}
+	if !circuit {
+		return nil, errors.New("graph has no Eulerian circuit")
+	}
+	return eg.walk(start)
+}
+
+// Returns an Eulerian path built with Hierholzer's algorithm
+// The walk lists the nodes in order, following every edge exactly once
+// If the graph has an Eulerian circuit the path is that circuit, otherwise it starts at the odd node
+// Returns an error if the graph has no edges or no Eulerian path
+// Examples
+// g.RemoveEdge("C", "A")
+// path, err := g.EulerianPath()
+// fmt.Println(len(path)) // Output: 3
+func (g *Graph[K, V]) EulerianPath() ([]K, error) {
+	eg := g.newEulerGraph()
+	start, _, err := eg.start()
+	if err != nil {
+		return nil, err
+	}
+	return eg.walk(start)
+}
+
+// Returns the cheapest closed walk from start that covers every edge at least once, the Chinese postman route
+// When the graph has no Eulerian circuit the cheapest paths between unbalanced nodes are walked twice:
+// in an undirected graph the odd nodes are paired by a minimum weight matching of their shortest paths,
+// in a directed graph a min cost flow sends the missing walks from nodes with too many incoming edges
+// Edge weights are lengths and must be non-negative
+// The matching is exact for up to 20 odd nodes, beyond that it is a greedy pairing improved by swaps
+// Returns an error if start is missing, some edge can't be reached from start, or the route can't close
+// Examples
+// g := New[string, int]("corridors", false)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 2)
+// route, err := g.ChinesePostman("A")
+// fmt.Println(route.Path, route.Cost) // Output: [A B C B A] 6
+func (g *Graph[K, V]) ChinesePostman(start K) (WeightedPath[K], error) {
+	if !g.ContainsNode(start) {
+		return WeightedPath[K]{}, errors.New("start node not in graph")
+	}
+	eg := g.newEulerGraph()
+	for _, e := range eg.edges {
+		if e.Weight < 0 {
+			return WeightedPath[K]{}, errors.New("edge weights must be non-negative")
+		}
+	}
+	if len(eg.edges) == 0 {
+		return WeightedPath[K]{Path: []K{start}}, nil
+	}
+	var err error
+	if g.IsDirected {
+		err = g.balanceDirected(eg)
+	} else {
+		err = g.pairOddNodes(eg)
+	}
+	if err != nil {
+		return WeightedPath[K]{}, err
+	}
+	walk, err := eg.walk(start)
+	if err != nil {
+		return WeightedPath[K]{}, errors.New("some edges can't be reached from start")
+	}
+	return WeightedPath[K]{Path: walk, Cost: eg.cost()}, nil
+}
+
+// Duplicates the shortest paths of a minimum weight matching of the odd degree nodes
+func (g *Graph[K, V]) pairOddNodes(eg *eulerGraph[K]) error {
+	odd := make([]K, 0)
+	for k, ids := range eg.adjacency {
+		if len(ids)%2 == 1 {
+			odd = append(odd, k)
+		}
+	}
+	dist := make([][]float64, len(odd))
+	parents := make([]map[K]K, len(odd))
+	for i, k := range odd {
+		d, p, _ := dijkstraTree(g.Edges, k, edgeWeight, nil)
+		parents[i] = p
+		dist[i] = make([]float64, len(odd))
+		for j, other := range odd {
+			cost, reached := d[other]
+			if !reached {
+				return errors.New("graph is not connected")
+			}
+			dist[i][j] = cost
+		}
+	}
+	for _, pair := range minWeightPerfectMatching(dist) {
+		path := constructPath(parents[pair[0]], odd[pair[0]], odd[pair[1]])
+		for i := 1; i < len(path); i++ {
+			eg.addEdge(Edge[K]{From: path[i-1], To: path[i], Weight: g.Edges[path[i-1]][path[i]]})
+		}
+	}
+	return nil
+}
+
+// Duplicates the edges carrying a min cost flow from nodes with more incoming than outgoing edges
+// to nodes with more outgoing edges, after which every node is balanced
+func (g *Graph[K, V]) balanceDirected(eg *eulerGraph[K]) error {
+	balance := make(map[K]float64)
+	for _, e := range eg.edges {
+		balance[e.From]--
+		balance[e.To]++
+	}
+	n := NewFlowNetwork[K]()
+	total := 0.0
+	for k, b := range balance {
+		n.SetSupply(k, b)
+		total += max(b, 0)
+	}
+	if total == 0 {
+		return nil
+	}
+	// No edge needs more extra walks than there are units to move
+	for _, e := range eg.edges {
+		if e.From != e.To {
+			n.AddEdge(e.From, e.To, total, e.Weight)
+		}
+	}
+	res, err := n.MinCostFlow()
+	if err != nil {
+		return fmt.Errorf("route can't close: %w", err)
+	}
+	for u, flows := range res.Flow {
+		for v, flow := range flows {
+			for range int(math.Round(flow)) {
+				eg.addEdge(Edge[K]{From: u, To: v, Weight: g.Edges[u][v]})
+			}
+		}
+	}
+	return nil
+}
+
+// Odd node counts up to this are matched exactly
+const exactMatchingLimit = 20
+
+// Returns a pairing of 0..len(dist)-1 with a low total distance, dist must be symmetric with an even size
+// Up to exactMatchingLimit nodes the pairing is optimal, found by dynamic programming over subsets;
+// larger inputs are paired greedily then improved by swapping partners between pairs
+func minWeightPerfectMatching(dist [][]float64) [][2]int {
+	n := len(dist)
+	pairs := make([][2]int, 0, n/2)
+	if n == 0 {
+		return pairs
+	}
+	if n <= exactMatchingLimit {
+		// best[mask] is the cheapest pairing of the nodes in mask, always pairing its lowest node first
+		full := 1<<n - 1
+		best := make([]float64, full+1)
+		choice := make([]int, full+1)
+		for mask := 1; mask <= full; mask++ {
+			best[mask] = math.Inf(1)
+			first := 0
+			for mask&(1<<first) == 0 {
+				first++
+			}
+			for j := first + 1; j < n; j++ {
+				if mask&(1<<j) == 0 {
+					continue
+				}
+				rest := mask &^ (1<<first | 1<<j)
+				if cost := dist[first][j] + best[rest]; cost < best[mask] {
+					best[mask] = cost
+					choice[mask] = j
+				}
+			}
+		}
+		for mask := full; mask != 0; {
+			first := 0
+			for mask&(1<<first) == 0 {
+				first++
+			}
+			j := choice[mask]
+			pairs = append(pairs, [2]int{first, j})
+			mask &^= 1<<first | 1<<j
+		}
+		return pairs
+	}
+
+	matched := make([]bool, n)
+	for i := 0; i < n; i++ {
+		if matched[i] {
+			continue
+		}
+		partner := -1
+		for j := i + 1; j < n; j++ {
+			if !matched[j] && (partner == -1 || dist[i][j] < dist[i][partner]) {
+				partner = j
+			}
+		}
+		matched[i], matched[partner] = true, true
+		pairs = append(pairs, [2]int{i, partner})
+	}
+	for improved := true; improved; {
+		improved = false
+		for a := range pairs {
+			for b := a + 1; b < len(pairs); b++ {
+				p, q := pairs[a], pairs[b]
+				current := dist[p[0]][p[1]] + dist[q[0]][q[1]]
+				if dist[p[0]][q[0]]+dist[p[1]][q[1]] < current-1e-12 {
+					pairs[a], pairs[b] = [2]int{p[0], q[0]}, [2]int{p[1], q[1]}
+					improved = true
+				} else if dist[p[0]][q[1]]+dist[p[1]][q[0]] < current-1e-12 {
+					pairs[a], pairs[b] = [2]int{p[0], q[1]}, [2]int{p[1], q[0]}
+					improved = true
+				}
+			}
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,230 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Checks that walk follows edges of g and covers every edge, exactly once if exact is set
+// Returns the total weight walked
+func checkEulerWalk(t *testing.T, g *Graph[int, int], walk []int, exact bool) float64 {
+	t.Helper()
+	used := make(map[[2]int]int)
+	cost := 0.0
+	for i := 1; i < len(walk); i++ {
+		u, v := walk[i-1], walk[i]
+		if !g.ContainsEdge(u, v) {
+			t.Fatalf("Walk %v uses missing edge %d-%d", walk, u, v)
+		}
+		if !g.IsDirected && v < u {
+			u, v = v, u
+		}
+		used[[2]int{u, v}]++
+		cost += g.Edges[u][v]
+	}
+	for u, edges := range g.Edges {
+		for v := range edges {
+			if !g.IsDirected && v < u {
+				continue
+			}
+			if n := used[[2]int{u, v}]; n == 0 || (exact && n != 1) {
+				t.Errorf("Walk %v uses edge %d-%d %d times", walk, u, v, n)
+			}
+		}
+	}
+	return cost
+}
+
+func TestEulerianUndirected(t *testing.T) {
+	g := New[int, int]("bowtie", false)
+	for i := 0; i < 6; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 2, 1)
+	g.AddEdge(4, 4, 1)
+	circuit, err := g.EulerianCircuit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(circuit) != 8 || circuit[0] != circuit[len(circuit)-1] {
+		t.Errorf("Circuit %v should close after 7 edges", circuit)
+	}
+	checkEulerWalk(t, g, circuit, true)
+
+	g.RemoveEdge(2, 0)
+	if g.HasEulerianCircuit() {
+		t.Error("Expected no circuit with two odd nodes")
+	}
+	path, err := g.EulerianPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ends := [2]int{min(path[0], path[len(path)-1]), max(path[0], path[len(path)-1])}; ends != [2]int{0, 2} {
+		t.Errorf("Path %v should run between the odd nodes 0 and 2", path)
+	}
+	checkEulerWalk(t, g, path, true)
+
+	g.AddEdge(3, 5, 1)
+	if g.HasEulerianPath() {
+		t.Error("Expected no path with four odd nodes")
+	}
+}
+
+func TestEulerianDisconnected(t *testing.T) {
+	g := New[int, int]("two triangles", false)
+	for i := 0; i < 6; i++ {
+		g.AddNode(i, i)
+	}
+	for _, base := range []int{0, 3} {
+		g.AddEdge(base, base+1, 1)
+		g.AddEdge(base+1, base+2, 1)
+		g.AddEdge(base+2, base, 1)
+	}
+	if _, err := g.EulerianCircuit(); err == nil {
+		t.Error("Expected an error when the edges are not connected")
+	}
+	if _, err := New[int, int]("empty", false).EulerianPath(); err == nil {
+		t.Error("Expected an error for a graph without edges")
+	}
+}
+
+func TestEulerianDirected(t *testing.T) {
+	g := New[int, int]("directed", true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(0, 3, 1)
+	g.AddEdge(3, 0, 1)
+	circuit, err := g.EulerianCircuit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEulerWalk(t, g, circuit, true)
+
+	g.RemoveEdge(3, 0)
+	path, err := g.EulerianPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path[0] != 0 || path[len(path)-1] != 3 {
+		t.Errorf("Path %v should run from 0 to 3", path)
+	}
+	checkEulerWalk(t, g, path, true)
+
+	g.AddEdge(1, 3, 1)
+	if g.HasEulerianPath() {
+		t.Error("Expected no path when node 3 has two extra incoming edges")
+	}
+}
+
+func TestChinesePostman(t *testing.T) {
+	// Unit square with a diagonal 0-2, the odd nodes 0 and 2 are joined most cheaply by the diagonal
+	g := New[int, int]("square", false)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 0, 1)
+	g.AddEdge(0, 2, 1.5)
+	route, err := g.ChinesePostman(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Cost != 7 || route.Path[0] != 1 || route.Path[len(route.Path)-1] != 1 {
+		t.Errorf("Route %v with cost %v, expected a closed walk from 1 costing 7", route.Path, route.Cost)
+	}
+	if cost := checkEulerWalk(t, g, route.Path, false); cost != route.Cost {
+		t.Errorf("Walk costs %v, route reports %v", cost, route.Cost)
+	}
+
+	d := New[int, int]("one way", true)
+	for i := 0; i < 3; i++ {
+		d.AddNode(i, i)
+	}
+	d.AddEdge(0, 1, 1)
+	d.AddEdge(1, 2, 1)
+	d.AddEdge(2, 0, 1)
+	d.AddEdge(0, 2, 5)
+	// 2 has an extra incoming edge, so the walk repeats the cheapest way back 2->0
+	route, err = d.ChinesePostman(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Cost != 9 {
+		t.Errorf("Directed route cost %v, expected 9", route.Cost)
+	}
+	checkEulerWalk(t, d, route.Path, false)
+
+	d.RemoveEdge(2, 0)
+	if _, err := d.ChinesePostman(0); err == nil {
+		t.Error("Expected an error when the route can't return to 0")
+	}
+	if route, err := New[int, int]("empty", false).ChinesePostman(0); err == nil {
+		t.Errorf("Expected an error for a missing start, got %v", route)
+	}
+}
+
+// Returns the cheapest way to pair up nodes by trying every pairing
+func bruteForcePairing(dist [][]float64, remaining []int) float64 {
+	if len(remaining) == 0 {
+		return 0
+	}
+	best := math.Inf(1)
+	for j := 1; j < len(remaining); j++ {
+		rest := make([]int, 0, len(remaining)-2)
+		rest = append(rest, remaining[1:j]...)
+		rest = append(rest, remaining[j+1:]...)
+		best = min(best, dist[remaining[0]][remaining[j]]+bruteForcePairing(dist, rest))
+	}
+	return best
+}
+
+func TestMinWeightPerfectMatching(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for _, n := range []int{2, 4, 6, 8, 10, 24} {
+		dist := make([][]float64, n)
+		for i := range dist {
+			dist[i] = make([]float64, n)
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dist[i][j] = float64(rng.Intn(50))
+				dist[j][i] = dist[i][j]
+			}
+		}
+		pairs := minWeightPerfectMatching(dist)
+		seen := make(map[int]bool)
+		total := 0.0
+		for _, p := range pairs {
+			if seen[p[0]] || seen[p[1]] || p[0] == p[1] {
+				t.Fatalf("Pairs %v reuse a node", pairs)
+			}
+			seen[p[0]], seen[p[1]] = true, true
+			total += dist[p[0]][p[1]]
+		}
+		if len(seen) != n {
+			t.Fatalf("Pairs %v leave nodes out", pairs)
+		}
+		if n > exactMatchingLimit {
+			continue
+		}
+		all := make([]int, n)
+		for i := range all {
+			all[i] = i
+		}
+		if want := bruteForcePairing(dist, all); total != want {
+			t.Errorf("Matching of %d nodes costs %v, expected %v", n, total, want)
+		}
+	}
+}
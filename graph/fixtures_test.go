@@ -0,0 +1,36 @@
+package graph
+
+import "math/rand"
+
+// Describes a random test graph over nodes 0..n-1, each holding its own key as value
+// Every pair of distinct nodes is joined with probability p; in a directed graph each direction is drawn
+// on its own, unless acyclic keeps only the edges from lower to higher keys
+// weight draws each edge weight, nil makes every weight 1
+type randomGraph struct {
+	n        int
+	p        float64
+	directed bool
+	acyclic  bool
+	weight   func(rng *rand.Rand) float64
+}
+
+// Returns the graph drawn from rng, the same seed always gives the same graph
+func (r randomGraph) build(rng *rand.Rand) *Graph[int, int] {
+	g := New[int, int]("random", r.directed)
+	for i := 0; i < r.n; i++ {
+		g.AddNode(i, i)
+	}
+	for i := 0; i < r.n; i++ {
+		for j := 0; j < r.n; j++ {
+			if i == j || (j < i && (!r.directed || r.acyclic)) || rng.Float64() >= r.p {
+				continue
+			}
+			weight := 1.0
+			if r.weight != nil {
+				weight = r.weight(rng)
+			}
+			g.AddEdge(i, j, weight)
+		}
+	}
+	return g
+}
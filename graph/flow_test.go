@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type maxFlowFunc func(source, sink int) (*FlowResult[int], error)
+
+func maxFlowAlgorithms(g *Graph[int, int]) map[string]maxFlowFunc {
+	return map[string]maxFlowFunc{
+		"EdmondsKarp": g.EdmondsKarp,
+		"Dinic":       g.Dinic,
+		"PushRelabel": g.PushRelabel,
+	}
+}
+
+// Checks capacities, conservation and that the cut matches the flow value
+func checkFlow(t *testing.T, name string, g *Graph[int, int], res *FlowResult[int], source, sink int) {
+	net := make(map[int]float64)
+	for u, edges := range res.Flow {
+		for v, flow := range edges {
+			if flow > g.GetEdgeWeight(u, v)+flowEpsilon {
+				t.Errorf("%s: flow %f on %d->%d exceeds capacity %f", name, flow, u, v, g.GetEdgeWeight(u, v))
+			}
+			net[u] -= flow
+			net[v] += flow
+		}
+	}
+	for k, balance := range net {
+		if k != source && k != sink && math.Abs(balance) > 1e-6 {
+			t.Errorf("%s: node %d has unbalanced flow %f", name, k, balance)
+		}
+	}
+	if math.Abs(net[sink]-res.Value) > 1e-6 {
+		t.Errorf("%s: sink receives %f, expected flow value %f", name, net[sink], res.Value)
+	}
+	if len(res.SourceSide)+len(res.SinkSide) != g.LengthNodes() {
+		t.Errorf("%s: cut sides hold %d nodes, expected %d", name, len(res.SourceSide)+len(res.SinkSide), g.LengthNodes())
+	}
+}
+
+func TestMaxFlow(t *testing.T) {
+	// Flow network from Introduction to Algorithms, maximum flow 23
+	g := New[int, int]("clrs", true)
+	for i := 0; i < 6; i++ {
+		g.AddNode(i, i)
+	}
+	g.AddEdge(0, 1, 16)
+	g.AddEdge(0, 2, 13)
+	g.AddEdge(2, 1, 4)
+	g.AddEdge(1, 3, 12)
+	g.AddEdge(3, 2, 9)
+	g.AddEdge(2, 4, 14)
+	g.AddEdge(4, 3, 7)
+	g.AddEdge(3, 5, 20)
+	g.AddEdge(4, 5, 4)
+
+	for name, run := range maxFlowAlgorithms(g) {
+		res, err := run(0, 5)
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if res.Value != 23 {
+			t.Errorf("%s: max flow %f, expected 23", name, res.Value)
+		}
+		if len(res.CutEdges) != 3 {
+			t.Errorf("%s: cut edges %v, expected 3 edges", name, res.CutEdges)
+		}
+		checkFlow(t, name, g, res, 0, 5)
+	}
+	if _, err := g.MaxFlow(0, 0); err == nil {
+		t.Error("Expected MaxFlow to reject equal source and sink")
+	}
+}
+
+func TestMaxFlowRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(12))
+	for round := 0; round < 10; round++ {
+		g := randomGraph{n: 40, p: 0.15, directed: round%2 == 0, weight: func(rng *rand.Rand) float64 {
+			return float64(rng.Intn(20))
+		}}.build(rng)
+		want := -1.0
+		for name, run := range maxFlowAlgorithms(g) {
+			res, err := run(0, 39)
+			if err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			if want < 0 {
+				want = res.Value
+			} else if math.Abs(res.Value-want) > 1e-6 {
+				t.Errorf("%s: max flow %f, other algorithms found %f", name, res.Value, want)
+			}
+			checkFlow(t, name, g, res, 0, 39)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package graph
 
 import (
-	"errors"
+	"context"
+	"iter"
+	"maps"
 
 	"main.go/helpers"
 )
@@ -15,11 +17,20 @@ type Graph[K comparable, V any] struct {
 	IsDirected bool
 }
 
+// Edge is a single weighted edge from one node to another
+type Edge[K comparable] struct {
+	From   K
+	To     K
+	Weight float64
+}
+
 type Node[K comparable, V any] struct {
-	Key         K
-	Value       V
+	Key   K
+	Value V
+	// Deprecated: searches keep their own costs in SearchResult and no longer write this field.
 	CurrentCost float64
-	Parent      *Node[K, V]
+	// Deprecated: searches keep their own parents in SearchResult and no longer write this field.
+	Parent *Node[K, V]
 }
 
 // GraphType is a string that represents the type of graph
@@ -38,6 +49,7 @@ func New[K comparable, V any](name string, isDirected bool) *Graph[K, V] {
 
 // Resets the current cost and parent of all nodes in the graph
 // This is useful for algorithms that need to reinitialize the graph state
+// Deprecated: the built-in searches no longer mutate nodes, so calling this between searches is unnecessary
 // Examples
 // g := New("MyGraph", true)
 // g.AddNode("A", 1)
@@ -206,6 +218,33 @@ func (g *Graph[K, V]) GetEdgeWeight(k1, k2 K) float64 {
 	return -1
 }
 
+// Returns the nodes reachable in one step from k with their edge weights
+// This makes Graph a Searchable, so the Search functions can run on it
+// Examples
+// g := New("MyGraph", true)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddEdge("A", "B", 3)
+// fmt.Println(g.Neighbors("A")) // Output: [{B 3}]
+func (g *Graph[K, V]) Neighbors(k K) []Successor[K] {
+	neighbors := make([]Successor[K], 0, len(g.Edges[k]))
+	for neighbor, weight := range g.Edges[k] {
+		neighbors = append(neighbors, Successor[K]{Key: neighbor, Cost: weight})
+	}
+	return neighbors
+}
+
+// Same as Neighbors but yields each neighbor and edge weight straight from the edge map
+// The searches prefer it, see NeighborIterator, so expanding a node of a Graph allocates no slice
+// Examples
+//
+//	for neighbor, weight := range g.NeighborSeq("A") {
+//	    fmt.Println(neighbor, weight) // Output: B 3
+//	}
+func (g *Graph[K, V]) NeighborSeq(k K) iter.Seq2[K, float64] {
+	return maps.All(g.Edges[k])
+}
+
 // Removes an edge between two nodes
 // If the edge does not exist, do nothing
 // If the graph is directed, the edge is removed in one direction
@@ -228,6 +267,24 @@ func (g *Graph[K, V]) RemoveEdge(k1, k2 K) {
 	}
 }
 
+// Returns the adjacency map with every edge pointing the other way
+// For an undirected graph this is just g.Edges
+func (g *Graph[K, V]) reverseEdges() map[K]map[K]float64 {
+	if !g.IsDirected {
+		return g.Edges
+	}
+	reverse := make(map[K]map[K]float64, len(g.Edges))
+	for u, edges := range g.Edges {
+		for v, weight := range edges {
+			if _, exists := reverse[v]; !exists {
+				reverse[v] = make(map[K]float64)
+			}
+			reverse[v][u] = weight
+		}
+	}
+	return reverse
+}
+
 // Returns a new undirected graph created from a 2D matrix with weights
 // The matrix should be a slice of slices of integers, where each integer
 // represents the weight of the corresponding edge in the grid.
@@ -298,35 +355,34 @@ func NewGraphFromMatrix(n string, matrix [][]float64, allowDiagonal bool) *Graph
 //	    fmt.Println("Visited:", visited) // Output: Visited: [A B C]
 //	}
 func (g *Graph[K, V]) BFS(start, end K) ([]K, []K, error) {
-
-	if start == end {
-		return []K{start}, []K{start}, nil
-	} else if !g.ContainsNode(start) || !g.ContainsNode(end) {
-		return nil, nil, errors.New("start or end node not in graph")
+	res, err := g.BFSResult(start, end)
+	if err != nil {
+		return nil, nil, err
 	}
+	return res.Path, res.Visited, nil
+}
 
-	visited := make(map[K]bool)
-	visited[start] = true
-	queue := []K{start}
-
-	for len(queue) > 0 {
-		node := queue[0]
-		queue = queue[1:]
+// Same as BFS but returns the full SearchResult
+// On failure the result still holds the nodes visited before the search gave up
+// Examples
+// res, err := g.BFSResult("A", "C")
+// fmt.Println(res.Path, res.Cost) // Output: [A B C] 2
+func (g *Graph[K, V]) BFSResult(start, end K) (*SearchResult[K], error) {
+	return g.BFSContext(context.Background(), start, end, SearchLimits{})
+}
 
-		for neighbor := range g.Edges[node] {
-			if visited[neighbor] {
-				continue
-			}
-			visited[neighbor] = true
-			g.Nodes[neighbor].Parent = g.Nodes[node]
-			if neighbor == end {
-				path := g.constructPath(neighbor)
-				return path, helpers.MapKeysToSlice(visited), nil
-			}
-			queue = append(queue, neighbor)
-		}
-	}
-	return nil, nil, errors.New("no path found")
+// Same as BFSResult but stops when ctx is done or limits.MaxExpansions is reached
+// An interrupted search returns an error wrapping ErrSearchInterrupted together with a partial result, see SearchLimits
+// Examples
+// ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+// defer cancel()
+// res, err := g.BFSContext(ctx, start, end, SearchLimits{MaxExpansions: 1000})
+//
+//	if errors.Is(err, ErrSearchInterrupted) {
+//	    fmt.Println("Got as far as", res.Path[len(res.Path)-1])
+//	}
+func (g *Graph[K, V]) BFSContext(ctx context.Context, start, end K, limits SearchLimits) (*SearchResult[K], error) {
+	return SearchBFS(ctx, g, start, end, limits)
 }
 
 // Returns a path from start to end using DFS
@@ -348,34 +404,33 @@ func (g *Graph[K, V]) BFS(start, end K) ([]K, []K, error) {
 //	    fmt.Println("Visited:", visited) // Output: Visited: [A B C]
 //	}
 func (g *Graph[K, V]) DFS(start, end K) ([]K, []K, error) {
-	if start == end {
-		return []K{start}, []K{start}, nil
-	} else if !g.ContainsNode(start) || !g.ContainsNode(end) {
-		return nil, nil, errors.New("start or end node not in graph")
+	res, err := g.DFSResult(start, end)
+	if err != nil {
+		return nil, nil, err
 	}
+	return res.Path, res.Visited, nil
+}
 
-	visited := make(map[K]bool)
-	visited[start] = true
-	stack := []K{start}
-
-	for len(stack) > 0 {
-		node := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
+// Same as DFS but returns the full SearchResult
+// Examples
+// res, err := g.DFSResult("A", "C")
+// fmt.Println(res.Path) // Output: [A B C]
+func (g *Graph[K, V]) DFSResult(start, end K) (*SearchResult[K], error) {
+	return g.DFSContext(context.Background(), start, end, SearchLimits{})
+}
 
-		for neighbor := range g.Edges[node] {
-			if visited[neighbor] {
-				continue
-			}
-			visited[neighbor] = true
-			g.Nodes[neighbor].Parent = g.Nodes[node]
-			if neighbor == end {
-				path := g.constructPath(neighbor)
-				return path, helpers.MapKeysToSlice(visited), nil
-			}
-			stack = append(stack, neighbor)
-		}
-	}
-	return nil, nil, errors.New("no path found")
+// Same as DFSResult but stops when ctx is done or limits.MaxExpansions is reached
+// An interrupted search returns an error wrapping ErrSearchInterrupted together with a partial result, see SearchLimits
+// Examples
+// ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+// defer cancel()
+// res, err := g.DFSContext(ctx, start, end, SearchLimits{MaxExpansions: 1000})
+//
+//	if errors.Is(err, ErrSearchInterrupted) {
+//	    fmt.Println("Got as far as", res.Path[len(res.Path)-1])
+//	}
+func (g *Graph[K, V]) DFSContext(ctx context.Context, start, end K, limits SearchLimits) (*SearchResult[K], error) {
+	return SearchDFS(ctx, g, start, end, limits)
 }
 
 // Returns a path from start to end using Dijkstra's algorithm (UCS)
@@ -398,45 +453,43 @@ func (g *Graph[K, V]) DFS(start, end K) ([]K, []K, error) {
 //	    fmt.Println("Visited:", visited) // Output: Visited: [A B C]
 //	}
 func (g *Graph[K, V]) Dijkstra(start, end K) ([]K, []K, error) {
-	if start == end {
-		return []K{start}, []K{start}, nil
-	} else if !g.ContainsNode(start) || !g.ContainsNode(end) {
-		return nil, nil, errors.New("start or end node not in graph")
+	res, err := g.DijkstraResult(start, end)
+	if err != nil {
+		return nil, nil, err
 	}
-	visited := make(map[K]bool)
-	pq := make(helpers.PriorityQueue[K], 0)
-	pq.PushItem(start, 0)
-	g.Nodes[start].CurrentCost = 0
-
-	for pq.Len() > 0 {
-		node := pq.PopItem()
-		if visited[node] {
-			continue
-		}
-		visited[node] = true
-		if node == end {
-			path := g.constructPath(node)
-			return path, helpers.MapKeysToSlice(visited), nil
-		}
-		for neighbor := range g.Edges[node] {
-			if visited[neighbor] {
-				continue
-			}
-			newCost := g.Nodes[node].CurrentCost + g.GetEdgeWeight(node, neighbor)
-			if g.Nodes[neighbor].Parent == nil || newCost < g.Nodes[neighbor].CurrentCost {
-				g.Nodes[neighbor].CurrentCost = newCost
-				g.Nodes[neighbor].Parent = g.Nodes[node]
-				pq.PushItem(neighbor, newCost)
-			}
-		}
-	}
-	return nil, nil, errors.New("no path found")
+	return res.Path, res.Visited, nil
+}
+
+// Same as Dijkstra but returns the full SearchResult
+// Visited is the order in which nodes were settled
+// Examples
+// res, err := g.DijkstraResult("A", "C")
+// fmt.Println(res.Path, res.Cost) // Output: [A C] 2
+func (g *Graph[K, V]) DijkstraResult(start, end K) (*SearchResult[K], error) {
+	return g.DijkstraContext(context.Background(), start, end, SearchLimits{})
+}
+
+// Same as DijkstraResult but stops when ctx is done or limits.MaxExpansions is reached
+// An interrupted search returns an error wrapping ErrSearchInterrupted together with a partial result, see SearchLimits
+// Examples
+// ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+// defer cancel()
+// res, err := g.DijkstraContext(ctx, start, end, SearchLimits{MaxExpansions: 1000})
+//
+//	if errors.Is(err, ErrSearchInterrupted) {
+//	    fmt.Println("Got as far as", res.Path[len(res.Path)-1])
+//	}
+func (g *Graph[K, V]) DijkstraContext(ctx context.Context, start, end K, limits SearchLimits) (*SearchResult[K], error) {
+	return SearchDijkstra(ctx, g, start, end, limits)
 }
 
 // Returns a path from start to end using A* algorithm
 // If no path exists, return nil
 // If the start or end node is not in the graph, return nil
 // The heuristic function should return the estimated cost from node a to node b
+// Nodes are expanded by f = g + heuristic(node, end) where g is the cost from start,
+// ties on f are broken towards the higher g
+// The path is optimal when the heuristic never overestimates, see CheckHeuristic
 // Examples
 // g := New("MyGraph", true)
 // g.AddNode("A", 1)
@@ -445,9 +498,11 @@ func (g *Graph[K, V]) Dijkstra(start, end K) ([]K, []K, error) {
 // g.AddEdge("A", "B", 2)
 // g.AddEdge("B", "C", 2)
 // g.AddEdge("A", "C", 2)
-// heuristic := func(a, b helpers.Coordinate) float64 {
-//     return 1 // Example heuristic, replace with actual heuristic logic
-// }
+//
+//	heuristic := func(a, b helpers.Coordinate) float64 {
+//	    return 1 // Example heuristic, replace with actual heuristic logic
+//	}
+//
 // path, visited, err := g.AStar("A", "C", heuristic)
 //
 //	if err != nil {
@@ -456,49 +511,42 @@ func (g *Graph[K, V]) Dijkstra(start, end K) ([]K, []K, error) {
 //	    fmt.Println("Path:", path) // Output: Path: [A C]
 //	    fmt.Println("Visited:", visited) // Output: Visited: [A B C]
 //	}
-
 func (g *Graph[K, V]) AStar(start, end K, heuristic func(a, b K) float64) ([]K, []K, error) {
-	if start == end {
-		return []K{start}, []K{start}, nil
-	} else if !g.ContainsNode(start) || !g.ContainsNode(end) {
-		return nil, nil, errors.New("start or end node not in graph")
+	res, err := g.AStarResult(start, end, heuristic)
+	if err != nil {
+		return nil, nil, err
 	}
-	visited := make(map[K]bool)
-	pq := make(helpers.PriorityQueue[K], 0)
-	pq.PushItem(start, 0)
-	g.Nodes[start].CurrentCost = 0
-
-	for pq.Len() > 0 {
-		node := pq.PopItem()
-		if visited[node] {
-			continue
-		}
-		visited[node] = true
-		if node == end {
-			path := g.constructPath(node)
-			return path, helpers.MapKeysToSlice(visited), nil
-		}
-		for neighbor := range g.Edges[node] {
-			if visited[neighbor] {
-				continue
-			}
-			newCost := g.Nodes[node].CurrentCost + g.GetEdgeWeight(node, neighbor) + heuristic(neighbor, end)
-			if g.Nodes[neighbor].Parent == nil || newCost < g.Nodes[neighbor].CurrentCost {
-				g.Nodes[neighbor].CurrentCost = newCost
-				g.Nodes[neighbor].Parent = g.Nodes[node]
-				pq.PushItem(neighbor, newCost)
-			}
-		}
-	}
-	return nil, nil, errors.New("no path found")
+	return res.Path, res.Visited, nil
+}
+
+// Same as AStar but returns the full SearchResult
+// Visited is the order in which nodes were expanded
+// Examples
+// res, err := g.AStarResult("A", "C", heuristic)
+// fmt.Println(res.Path, res.Cost) // Output: [A C] 2
+func (g *Graph[K, V]) AStarResult(start, end K, heuristic func(a, b K) float64) (*SearchResult[K], error) {
+	return g.AStarContext(context.Background(), start, end, heuristic, SearchLimits{})
+}
+
+// Same as AStarResult but stops when ctx is done or limits.MaxExpansions is reached
+// An interrupted search returns an error wrapping ErrSearchInterrupted together with a partial result, see SearchLimits
+// Examples
+// ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+// defer cancel()
+// res, err := g.AStarContext(ctx, start, end, heuristic, SearchLimits{MaxExpansions: 1000})
+//
+//	if errors.Is(err, ErrSearchInterrupted) {
+//	    fmt.Println("Got as far as", res.Path[len(res.Path)-1])
+//	}
+func (g *Graph[K, V]) AStarContext(ctx context.Context, start, end K, heuristic func(a, b K) float64, limits SearchLimits) (*SearchResult[K], error) {
+	return SearchAStar(ctx, g, start, end, heuristic, limits)
 }
 
-func (g *Graph[K, V]) constructPath(k K) []K {
-	path := make([]K, 0)
-	for g.Nodes[k].Parent != nil {
-		path = append([]K{k}, path...)
-		k = g.Nodes[k].Parent.Key
+// Returns the sum of the edge weights along path
+func (g *Graph[K, V]) pathCost(path []K) float64 {
+	total := 0.0
+	for i := 1; i < len(path); i++ {
+		total += g.Edges[path[i-1]][path[i]]
 	}
-	path = append([]K{k}, path...)
-	return path
+	return total
 }
@@ -1,7 +1,10 @@
 package graph
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
 
 	"main.go/helpers"
@@ -188,3 +191,129 @@ func TestSearch(t *testing.T) {
 	}
 
 }
+
+func TestSearchResultStateless(t *testing.T) {
+	matrix := [][]float64{
+		{0, 1, 2, -1, 0},
+		{2, 0, 0, -1, -1},
+		{2, -1, 0, 1, 4},
+		{-1, 3, 2, 0, 2},
+		{-1, -1, 4, 3, 0},
+	}
+	g := NewGraphFromMatrix("testGraph", matrix, false)
+	start := helpers.Coordinate{X: 0, Y: 0}
+	end := helpers.Coordinate{X: 4, Y: 4}
+
+	want, err := g.DijkstraResult(start, end)
+	if err != nil {
+		t.Fatalf("Dijkstra failed: %v", err)
+	}
+	if want.Cost != 4 {
+		t.Errorf("Expected cost 4, got %f", want.Cost)
+	}
+	if want.Visited[0] != start || want.Visited[len(want.Visited)-1] != end {
+		t.Errorf("Expected expansion order to start at %v and end at %v, got %v", start, end, want.Visited)
+	}
+	if want.Parents[end] != want.Path[len(want.Path)-2] {
+		t.Errorf("Expected parent of end to be %v, got %v", want.Path[len(want.Path)-2], want.Parents[end])
+	}
+
+	// Back to back searches without ResetNodes must agree
+	if _, _, err := g.BFS(start, end); err != nil {
+		t.Errorf("BFS failed: %v", err)
+	}
+	again, err := g.DijkstraResult(start, end)
+	if err != nil || !reflect.DeepEqual(again.Path, want.Path) {
+		t.Errorf("Second Dijkstra found path %v, expected %v", again.Path, want.Path)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 32)
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var res *SearchResult[helpers.Coordinate]
+			var err error
+			switch i % 4 {
+			case 0:
+				res, err = g.BFSResult(start, end)
+			case 1:
+				res, err = g.DFSResult(start, end)
+			case 2:
+				res, err = g.DijkstraResult(start, end)
+			default:
+				res, err = g.AStarResult(start, end, helpers.EuclideanDistance)
+			}
+			if err != nil {
+				errs <- err.Error()
+				return
+			}
+			if res.Path[0] != start || res.Path[len(res.Path)-1] != end {
+				errs <- "path does not connect start and end"
+			}
+			if i%4 == 2 && !reflect.DeepEqual(res.Path, want.Path) {
+				errs <- "concurrent Dijkstra found a different path"
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for e := range errs {
+		t.Error(e)
+	}
+}
+
+func TestSearchContext(t *testing.T) {
+	matrix := make([][]float64, 30)
+	for i := range matrix {
+		matrix[i] = make([]float64, 30)
+		for j := range matrix[i] {
+			matrix[i][j] = 1
+		}
+	}
+	g := NewGraphFromMatrix("testGraph", matrix, false)
+	start := helpers.Coordinate{X: 0, Y: 0}
+	end := helpers.Coordinate{X: 29, Y: 29}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := g.DijkstraContext(ctx, start, end, SearchLimits{})
+	if !errors.Is(err, ErrSearchInterrupted) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancelled search error, got %v", err)
+	}
+
+	limits := SearchLimits{MaxExpansions: 50}
+	searches := map[string]func() (*SearchResult[helpers.Coordinate], error){
+		"BFS": func() (*SearchResult[helpers.Coordinate], error) {
+			return g.BFSContext(context.Background(), start, end, limits)
+		},
+		"DFS": func() (*SearchResult[helpers.Coordinate], error) {
+			return g.DFSContext(context.Background(), start, end, limits)
+		},
+		"Dijkstra": func() (*SearchResult[helpers.Coordinate], error) {
+			return g.DijkstraContext(context.Background(), start, end, limits)
+		},
+		"AStar": func() (*SearchResult[helpers.Coordinate], error) {
+			return g.AStarContext(context.Background(), start, end, helpers.EuclideanDistance, limits)
+		},
+	}
+	for name, search := range searches {
+		res, err := search()
+		if !errors.Is(err, ErrSearchInterrupted) || !errors.Is(err, ErrExpansionLimit) {
+			t.Errorf("%s: expected expansion limit error, got %v", name, err)
+			continue
+		}
+		if len(res.Path) < 2 || res.Path[0] != start {
+			t.Errorf("%s: expected partial path from start, got %v", name, res.Path)
+			continue
+		}
+		if res.Cost != float64(len(res.Path)-1) {
+			t.Errorf("%s: expected partial cost %d, got %f", name, len(res.Path)-1, res.Cost)
+		}
+	}
+
+	if _, err := g.AStarContext(context.Background(), start, end, helpers.EuclideanDistance, SearchLimits{MaxExpansions: 10000}); err != nil {
+		t.Errorf("Expected search within budget to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Slack allowed when comparing floating point costs
+const heuristicTolerance = 1e-9
+
+// HeuristicError reports where a heuristic breaks the rules A* relies on
+// Inconsistent lists edges u->v where heuristic(u, End) > weight + heuristic(v, End)
+// Inadmissible lists nodes where heuristic(node, End) is more than the true cost to End
+type HeuristicError[K comparable] struct {
+	End          K
+	Inconsistent []Edge[K]
+	Inadmissible []K
+}
+
+func (e *HeuristicError[K]) Error() string {
+	return fmt.Sprintf("heuristic to %v is inconsistent on %d edges and inadmissible at %d nodes",
+		e.End, len(e.Inconsistent), len(e.Inadmissible))
+}
+
+// Checks heuristic against every node and edge of the graph for the goal end
+// A heuristic is admissible if it never overestimates the cost to end,
+// and consistent if it never drops by more than the edge weight along an edge
+// Returns nil if both hold, otherwise a *HeuristicError listing the offenders
+// Examples
+// err := g.CheckHeuristic(end, helpers.EuclideanDistance)
+//
+//	var hErr *HeuristicError[helpers.Coordinate]
+//	if errors.As(err, &hErr) {
+//	    fmt.Println("Bad edges:", hErr.Inconsistent)
+//	}
+func (g *Graph[K, V]) CheckHeuristic(end K, heuristic func(a, b K) float64) error {
+	if !g.ContainsNode(end) {
+		return errors.New("end node not in graph")
+	}
+	hErr := &HeuristicError[K]{End: end}
+	for u, edges := range g.Edges {
+		for v, weight := range edges {
+			if heuristic(u, end) > weight+heuristic(v, end)+heuristicTolerance {
+				hErr.Inconsistent = append(hErr.Inconsistent, Edge[K]{From: u, To: v, Weight: weight})
+			}
+		}
+	}
+	// True costs to end are the distances from end over the reversed edges
+	dist, _, _ := dijkstraTree(g.reverseEdges(), end, edgeWeight, nil)
+	for k := range g.Nodes {
+		toEnd, reachable := dist[k]
+		if !reachable {
+			toEnd = math.Inf(1)
+		}
+		if heuristic(k, end) > toEnd+heuristicTolerance {
+			hErr.Inadmissible = append(hErr.Inadmissible, k)
+		}
+	}
+	if len(hErr.Inconsistent) == 0 && len(hErr.Inadmissible) == 0 {
+		return nil
+	}
+	return hErr
+}
+
+// Debug version of AStarResult that checks the heuristic before searching
+// If the search succeeds but the heuristic is not admissible or consistent,
+// the result is returned together with the *HeuristicError from CheckHeuristic
+// Examples
+// res, err := g.AStarDebug(start, end, heuristic)
+//
+//	var hErr *HeuristicError[helpers.Coordinate]
+//	if errors.As(err, &hErr) {
+//	    fmt.Println("Path may not be optimal:", res.Path, hErr)
+//	}
+func (g *Graph[K, V]) AStarDebug(start, end K, heuristic func(a, b K) float64) (*SearchResult[K], error) {
+	res, err := g.AStarResult(start, end, heuristic)
+	if err != nil {
+		return res, err
+	}
+	return res, g.CheckHeuristic(end, heuristic)
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"main.go/helpers"
+)
+
+// Builds a random graph on points in the plane where no edge is shorter than the straight line
+func newRandomPlaneGraph(rng *rand.Rand, n, m int, directed bool) *Graph[helpers.Coordinate, int] {
+	g := New[helpers.Coordinate, int]("plane", directed)
+	points := make([]helpers.Coordinate, n)
+	for i := range points {
+		points[i] = helpers.Coordinate{X: rng.Float64() * 100, Y: rng.Float64() * 100}
+		g.AddNode(points[i], i)
+	}
+	for i := 0; i < m; i++ {
+		a, b := points[rng.Intn(n)], points[rng.Intn(n)]
+		g.AddEdge(a, b, helpers.EuclideanDistance(a, b)*(1+rng.Float64()))
+	}
+	return g
+}
+
+func TestAStarMatchesDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	for round := 0; round < 20; round++ {
+		g := newRandomPlaneGraph(rng, 80, 300, round%2 == 0)
+		keys := helpers.MapKeysToSlice(g.Nodes)
+		for i := 0; i < 20; i++ {
+			start, end := keys[rng.Intn(len(keys))], keys[rng.Intn(len(keys))]
+			want, wantErr := g.DijkstraResult(start, end)
+			got, err := g.AStarDebug(start, end, helpers.EuclideanDistance)
+			if wantErr != nil {
+				if err == nil {
+					t.Errorf("A* found %v where Dijkstra found no path", got.Path)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("A* failed: %v", err)
+			}
+			if math.Abs(got.Cost-want.Cost) > 1e-9 {
+				t.Errorf("A* cost %f, Dijkstra cost %f", got.Cost, want.Cost)
+			}
+			if len(got.Visited) > len(want.Visited) {
+				t.Errorf("A* expanded %d nodes, more than Dijkstra's %d", len(got.Visited), len(want.Visited))
+			}
+		}
+	}
+}
+
+func TestAStarInconsistentHeuristic(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	g := newRandomPlaneGraph(rng, 80, 300, false)
+	keys := helpers.MapKeysToSlice(g.Nodes)
+	end := keys[0]
+	exact, err := g.DijkstraAll(end)
+	if err != nil {
+		t.Fatalf("DijkstraAll failed: %v", err)
+	}
+	// Scaling the true distance by a random factor per node stays admissible but is rarely consistent
+	scale := make(map[helpers.Coordinate]float64)
+	for _, k := range keys {
+		scale[k] = rng.Float64()
+	}
+	heuristic := func(a, b helpers.Coordinate) float64 {
+		if math.IsInf(exact.Dist[a], 1) {
+			return 0
+		}
+		return exact.Dist[a] * scale[a]
+	}
+
+	var hErr *HeuristicError[helpers.Coordinate]
+	if err := g.CheckHeuristic(end, heuristic); !errors.As(err, &hErr) || len(hErr.Inconsistent) == 0 {
+		t.Fatalf("Expected inconsistent edges, got %v", err)
+	}
+	if len(hErr.Inadmissible) != 0 {
+		t.Errorf("Expected heuristic to be admissible, got %v", hErr.Inadmissible)
+	}
+	for _, start := range keys[1:] {
+		res, err := g.AStarResult(start, end, heuristic)
+		if math.IsInf(exact.Dist[start], 1) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("A* failed: %v", err)
+		}
+		if math.Abs(res.Cost-exact.Dist[start]) > 1e-9 {
+			t.Errorf("A* cost %f, expected %f", res.Cost, exact.Dist[start])
+		}
+	}
+}
+
+func TestCheckHeuristic(t *testing.T) {
+	matrix := [][]float64{
+		{0, 1, 2},
+		{2, 0, 0},
+		{2, 1, 0},
+	}
+	g := NewGraphFromMatrix("grid", matrix, false)
+	end := helpers.Coordinate{X: 2, Y: 2}
+
+	if err := g.CheckHeuristic(end, func(a, b helpers.Coordinate) float64 { return 0 }); err != nil {
+		t.Errorf("Expected zero heuristic to pass, got %v", err)
+	}
+
+	// Cells cost as little as 0, so straight line distance overestimates
+	var hErr *HeuristicError[helpers.Coordinate]
+	if err := g.CheckHeuristic(end, helpers.EuclideanDistance); !errors.As(err, &hErr) {
+		t.Fatalf("Expected HeuristicError, got %v", err)
+	}
+	if len(hErr.Inadmissible) == 0 || len(hErr.Inconsistent) == 0 {
+		t.Errorf("Expected inadmissible nodes and inconsistent edges, got %v and %v", hErr.Inadmissible, hErr.Inconsistent)
+	}
+	for _, e := range hErr.Inconsistent {
+		if helpers.EuclideanDistance(e.From, end) <= e.Weight+helpers.EuclideanDistance(e.To, end) {
+			t.Errorf("Edge %v reported inconsistent but is not", e)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Successor is a state reachable in one step together with the cost of that step
+type Successor[K comparable] struct {
+	Key  K
+	Cost float64
+}
+
+// SuccessorFunc returns the states reachable in one step from state
+// It lets the memory-bound searches explore state spaces that are too large to build as a Graph
+type SuccessorFunc[K comparable] func(state K) []Successor[K]
+
+// Walks a single path through an implicit state space
+// Only the current path is kept, so memory grows with depth and not with the number of states
+type pathWalker[K comparable] struct {
+	ctx        context.Context
+	goal       K
+	successors SuccessorFunc[K]
+	path       []K
+	onPath     map[K]bool
+}
+
+func newPathWalker[K comparable](ctx context.Context, start, goal K, successors SuccessorFunc[K]) *pathWalker[K] {
+	return &pathWalker[K]{
+		ctx:        ctx,
+		goal:       goal,
+		successors: successors,
+		path:       []K{start},
+		onPath:     map[K]bool{start: true},
+	}
+}
+
+func (w *pathWalker[K]) push(k K) {
+	w.path = append(w.path, k)
+	w.onPath[k] = true
+}
+
+func (w *pathWalker[K]) pop() {
+	delete(w.onPath, w.path[len(w.path)-1])
+	w.path = w.path[:len(w.path)-1]
+}
+
+func (w *pathWalker[K]) interrupted() error {
+	if err := w.ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrSearchInterrupted, err)
+	}
+	return nil
+}
+
+// Returns a lowest cost path from start to goal using iterative-deepening A*
+// Runs repeated depth-first searches, each bounded by f = g + heuristic(state, goal),
+// raising the bound to the smallest f that went over it until goal is reached
+// The heuristic has the same signature AStar accepts and must not overestimate for the path to be optimal
+// States are only checked against the current path, so memory use grows with the depth of the solution
+// Stops with an error wrapping ErrSearchInterrupted when ctx is done
+// Examples
+//
+//	successors := func(s Puzzle) []Successor[Puzzle] {
+//	    // return every state one slide away, each with cost 1
+//	}
+//
+// path, cost, err := IDAStar(ctx, start, solved, successors, manhattan)
+// fmt.Println(len(path)-1, cost) // Output: 20 20
+func IDAStar[K comparable](ctx context.Context, start, goal K, successors SuccessorFunc[K], heuristic func(a, b K) float64) ([]K, float64, error) {
+	w := newPathWalker(ctx, start, goal, successors)
+	bound := heuristic(start, goal)
+	for {
+		next, found, err := idaSearch(w, heuristic, 0, bound)
+		if err != nil {
+			return nil, 0, err
+		}
+		if found {
+			return w.path, next, nil
+		}
+		if math.IsInf(next, 1) {
+			return nil, 0, errors.New("no path found")
+		}
+		bound = next
+	}
+}
+
+// Searches below the last state on the walker's path
+// Returns the cost of the path if goal was found, otherwise the smallest f that exceeded bound
+func idaSearch[K comparable](w *pathWalker[K], heuristic func(a, b K) float64, g, bound float64) (float64, bool, error) {
+	if err := w.interrupted(); err != nil {
+		return 0, false, err
+	}
+	state := w.path[len(w.path)-1]
+	f := g + heuristic(state, w.goal)
+	if f > bound {
+		return f, false, nil
+	}
+	if state == w.goal {
+		return g, true, nil
+	}
+	next := math.Inf(1)
+	for _, s := range w.successors(state) {
+		if w.onPath[s.Key] {
+			continue
+		}
+		w.push(s.Key)
+		t, found, err := idaSearch(w, heuristic, g+s.Cost, bound)
+		if err != nil || found {
+			return t, found, err
+		}
+		w.pop()
+		next = min(next, t)
+	}
+	return next, false, nil
+}
+
+// Returns a path from start to goal that uses at most limit steps, searching depth first
+// Returns an error if no such path exists or limit is negative
+// Examples
+// path, cost, err := DepthLimitedDFS(ctx, start, goal, successors, 10)
+func DepthLimitedDFS[K comparable](ctx context.Context, start, goal K, successors SuccessorFunc[K], limit int) ([]K, float64, error) {
+	if limit < 0 {
+		return nil, 0, fmt.Errorf("depth limit %d is negative", limit)
+	}
+	w := newPathWalker(ctx, start, goal, successors)
+	cost, found, _, err := depthLimitedSearch(w, 0, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !found {
+		return nil, 0, fmt.Errorf("no path found within %d steps", limit)
+	}
+	return w.path, cost, nil
+}
+
+// Returns a path from start to goal with the fewest steps using iterative-deepening DFS
+// Runs DepthLimitedDFS with limits 0, 1, 2, ... so it finds the same path length as BFS
+// while only keeping the current path in memory
+// maxDepth caps the limit, 0 means no cap
+// Stops early with "no path found" once a pass explores the whole reachable space without hitting the limit
+// Examples
+// path, cost, err := IterativeDeepeningDFS(ctx, start, goal, successors, 0)
+// fmt.Println(len(path) - 1) // Output: 20
+func IterativeDeepeningDFS[K comparable](ctx context.Context, start, goal K, successors SuccessorFunc[K], maxDepth int) ([]K, float64, error) {
+	w := newPathWalker(ctx, start, goal, successors)
+	for limit := 0; maxDepth <= 0 || limit <= maxDepth; limit++ {
+		cost, found, cutoff, err := depthLimitedSearch(w, 0, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		if found {
+			return w.path, cost, nil
+		}
+		if !cutoff {
+			break
+		}
+	}
+	return nil, 0, errors.New("no path found")
+}
+
+// Searches below the last state on the walker's path for at most limit more steps
+// Returns the cost of the path if goal was found, and whether any branch was cut off by the limit
+func depthLimitedSearch[K comparable](w *pathWalker[K], g float64, limit int) (float64, bool, bool, error) {
+	if err := w.interrupted(); err != nil {
+		return 0, false, false, err
+	}
+	state := w.path[len(w.path)-1]
+	if state == w.goal {
+		return g, true, false, nil
+	}
+	successors := w.successors(state)
+	if limit == 0 {
+		// Only successors off the path could be explored by a deeper pass
+		for _, s := range successors {
+			if !w.onPath[s.Key] {
+				return 0, false, true, nil
+			}
+		}
+		return 0, false, false, nil
+	}
+	cutoff := false
+	for _, s := range successors {
+		if w.onPath[s.Key] {
+			continue
+		}
+		w.push(s.Key)
+		cost, found, cut, err := depthLimitedSearch(w, g+s.Cost, limit-1)
+		if err != nil || found {
+			return cost, found, false, err
+		}
+		w.pop()
+		cutoff = cutoff || cut
+	}
+	return 0, false, cutoff, nil
+}
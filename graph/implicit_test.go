@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"main.go/helpers"
+)
+
+// 8-puzzle board, 0 is the blank
+type puzzle [9]int
+
+var solvedPuzzle = puzzle{1, 2, 3, 4, 5, 6, 7, 8, 0}
+
+func puzzleSuccessors(p puzzle) []Successor[puzzle] {
+	blank := 0
+	for i, v := range p {
+		if v == 0 {
+			blank = i
+		}
+	}
+	successors := make([]Successor[puzzle], 0, 4)
+	for _, dir := range helpers.GetGridDirections(false) {
+		row, col := blank/3+dir[0], blank%3+dir[1]
+		if row < 0 || row > 2 || col < 0 || col > 2 {
+			continue
+		}
+		next := p
+		next[blank], next[row*3+col] = next[row*3+col], next[blank]
+		successors = append(successors, Successor[puzzle]{Key: next, Cost: 1})
+	}
+	return successors
+}
+
+func puzzleManhattan(a, b puzzle) float64 {
+	position := make(map[int]int, 9)
+	for i, v := range b {
+		position[v] = i
+	}
+	total := 0
+	for i, v := range a {
+		if v == 0 {
+			continue
+		}
+		j := position[v]
+		total += helpers.IntegerAbsoluteValue(i/3-j/3) + helpers.IntegerAbsoluteValue(i%3-j%3)
+	}
+	return float64(total)
+}
+
+func scramblePuzzle(rng *rand.Rand, moves int) puzzle {
+	p := solvedPuzzle
+	for i := 0; i < moves; i++ {
+		successors := puzzleSuccessors(p)
+		p = successors[rng.Intn(len(successors))].Key
+	}
+	return p
+}
+
+func TestIDAStarPuzzle(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		start := scramblePuzzle(rng, 16)
+		path, cost, err := IDAStar(ctx, start, solvedPuzzle, puzzleSuccessors, puzzleManhattan)
+		if err != nil {
+			t.Fatalf("IDAStar failed: %v", err)
+		}
+		if path[0] != start || path[len(path)-1] != solvedPuzzle || cost != float64(len(path)-1) {
+			t.Fatalf("IDAStar returned invalid path of %d states at cost %f", len(path), cost)
+		}
+		ddPath, ddCost, err := IterativeDeepeningDFS(ctx, start, solvedPuzzle, puzzleSuccessors, 0)
+		if err != nil {
+			t.Fatalf("IterativeDeepeningDFS failed: %v", err)
+		}
+		if ddCost != cost || len(ddPath) != len(path) {
+			t.Errorf("IterativeDeepeningDFS cost %f, IDAStar cost %f", ddCost, cost)
+		}
+		if cost == 0 {
+			continue
+		}
+		if _, _, err := DepthLimitedDFS(ctx, start, solvedPuzzle, puzzleSuccessors, int(cost)-1); err == nil {
+			t.Errorf("DepthLimitedDFS found a path shorter than the optimal %f", cost)
+		}
+	}
+}
+
+func TestImplicitSearchLimits(t *testing.T) {
+	// A small finite chain 0 -> 1 -> 2 with no way to reach 5
+	chain := func(k int) []Successor[int] {
+		if k >= 2 {
+			return nil
+		}
+		return []Successor[int]{{Key: k + 1, Cost: 1}}
+	}
+	ctx := context.Background()
+	zero := func(a, b int) float64 { return 0 }
+	if _, _, err := IDAStar(ctx, 0, 5, chain, zero); err == nil {
+		t.Error("Expected IDAStar to report no path")
+	}
+	if _, _, err := IterativeDeepeningDFS(ctx, 0, 5, chain, 0); err == nil {
+		t.Error("Expected IterativeDeepeningDFS to report no path")
+	}
+
+	// In the reversible line 0 - 1 - 2 - 3 the pass reaching 3 at the limit explores everything,
+	// stepping back to 2 is not a reason for one more pass
+	expanded := make(map[int]int)
+	line4 := func(k int) []Successor[int] {
+		expanded[k]++
+		next := make([]Successor[int], 0)
+		for _, v := range []int{k - 1, k + 1} {
+			if v >= 0 && v <= 3 {
+				next = append(next, Successor[int]{Key: v, Cost: 1})
+			}
+		}
+		return next
+	}
+	if _, _, err := IterativeDeepeningDFS(ctx, 0, 5, line4, 0); err == nil {
+		t.Error("Expected IterativeDeepeningDFS to report no path")
+	}
+	if expanded[3] != 1 {
+		t.Errorf("Expected the last pass to reach 3 once, it was expanded %d times", expanded[3])
+	}
+
+	// An infinite line only stops through the context
+	line := func(k int) []Successor[int] {
+		return []Successor[int]{{Key: k + 1, Cost: 1}, {Key: k - 1, Cost: 1}}
+	}
+	if _, _, err := DepthLimitedDFS(ctx, 0, 5, line, -1); err == nil {
+		t.Error("Expected DepthLimitedDFS to reject a negative limit")
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := IDAStar(cancelled, 0, 5, line, zero); !errors.Is(err, ErrSearchInterrupted) {
+		t.Errorf("Expected IDAStar to be interrupted, got %v", err)
+	}
+	if _, _, err := IterativeDeepeningDFS(cancelled, 0, 5, line, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected IterativeDeepeningDFS to be cancelled, got %v", err)
+	}
+}
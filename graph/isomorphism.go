@@ -0,0 +1,408 @@
+package graph
+
+import (
+	"slices"
+)
+
+// MatchOptions tunes the VF2 matchers
+// NodeMatch reports whether a node with value a may map to a node with value b, nil accepts any pair
+// EdgeMatch reports whether an edge of weight a may map to an edge of weight b, nil accepts any pair
+// Induced makes SubgraphIsomorphisms also require pattern nodes that are not joined to map to nodes that
+// are not joined, otherwise extra edges in the graph are allowed; isomorphisms always match both ways
+// Limit stops the search after that many mappings, 0 finds them all
+type MatchOptions[V1, V2 any] struct {
+	NodeMatch func(a V1, b V2) bool
+	EdgeMatch func(a, b float64) bool
+	Induced   bool
+	Limit     int
+}
+
+// How strictly the pattern has to match the graph
+type vf2Mode int
+
+const (
+	vf2Isomorphism vf2Mode = iota
+	vf2Induced
+	vf2Monomorphism
+)
+
+// Graph over node indexes, pred equals succ for an undirected graph
+type vf2Graph struct {
+	succ []map[int]float64
+	pred []map[int]float64
+}
+
+// Returns the keys of g and its index graph
+// Keys are ordered by decreasing degree, the matcher extends the pattern in this order so the most
+// constrained nodes are placed first
+func newVF2Graph[K comparable, V any](g *Graph[K, V]) ([]K, *vf2Graph) {
+	keys := g.sortedKeys()
+	slices.SortStableFunc(keys, func(a, b K) int { return len(g.Edges[b]) - len(g.Edges[a]) })
+	index := make(map[K]int, len(keys))
+	for i, k := range keys {
+		index[k] = i
+	}
+	vg := &vf2Graph{succ: make([]map[int]float64, len(keys))}
+	for i, k := range keys {
+		vg.succ[i] = make(map[int]float64, len(g.Edges[k]))
+		for v, weight := range g.Edges[k] {
+			vg.succ[i][index[v]] = weight
+		}
+	}
+	vg.pred = vg.succ
+	if g.IsDirected {
+		vg.pred = make([]map[int]float64, len(keys))
+		for i := range vg.pred {
+			vg.pred[i] = make(map[int]float64)
+		}
+		for i, edges := range vg.succ {
+			for j, weight := range edges {
+				vg.pred[j][i] = weight
+			}
+		}
+	}
+	return keys, vg
+}
+
+// State of a VF2 search mapping the pattern g2 into the graph g1
+// core1[n1] is the pattern node mapped to n1 and core2[n2] the graph node mapped to n2, -1 if unmapped
+// out and in hold the depth at which an unmapped node became a successor or predecessor of the mapping,
+// 0 while it is neither; these are the terminal sets VF2 draws candidates from
+type vf2Matcher struct {
+	g1, g2               *vf2Graph
+	directed             bool
+	mode                 vf2Mode
+	nodeMatch            func(n1, n2 int) bool
+	edgeMatch            func(a, b float64) bool
+	core1, core2         []int
+	in1, out1, in2, out2 []int
+	depth                int
+	limit                int
+	results              [][]int
+}
+
+func newVF2Matcher(g1, g2 *vf2Graph, directed bool, mode vf2Mode) *vf2Matcher {
+	m := &vf2Matcher{g1: g1, g2: g2, directed: directed, mode: mode}
+	n1, n2 := len(g1.succ), len(g2.succ)
+	m.core1, m.in1, m.out1 = make([]int, n1), make([]int, n1), make([]int, n1)
+	m.core2, m.in2, m.out2 = make([]int, n2), make([]int, n2), make([]int, n2)
+	for i := range m.core1 {
+		m.core1[i] = -1
+	}
+	for i := range m.core2 {
+		m.core2[i] = -1
+	}
+	return m
+}
+
+// Finds mappings of every pattern node until the limit is reached, returns false once it is
+func (m *vf2Matcher) match() bool {
+	if m.depth == len(m.core2) {
+		m.results = append(m.results, slices.Clone(m.core2))
+		return m.limit == 0 || len(m.results) < m.limit
+	}
+	for _, pair := range m.candidates() {
+		n1, n2 := pair[0], pair[1]
+		if !m.feasible(n1, n2) {
+			continue
+		}
+		changed := m.push(n1, n2)
+		more := m.match()
+		m.pop(n1, n2, changed)
+		if !more {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the pairs to try next: every unmapped graph node against a single pattern node,
+// taken from the outgoing terminal sets, else the incoming ones, else any unmapped nodes
+func (m *vf2Matcher) candidates() [][2]int {
+	terminals := [][2][]int{{m.out1, m.out2}}
+	if m.directed {
+		terminals = append(terminals, [2][]int{m.in1, m.in2})
+	}
+	for _, sets := range terminals {
+		n2 := m.firstUnmapped(m.core2, sets[1])
+		if n2 == -1 {
+			continue
+		}
+		pairs := make([][2]int, 0)
+		for n1, c := range m.core1 {
+			if c == -1 && sets[0][n1] > 0 {
+				pairs = append(pairs, [2]int{n1, n2})
+			}
+		}
+		if len(pairs) > 0 {
+			return pairs
+		}
+	}
+	n2 := m.firstUnmapped(m.core2, nil)
+	pairs := make([][2]int, 0)
+	for n1, c := range m.core1 {
+		if c == -1 {
+			pairs = append(pairs, [2]int{n1, n2})
+		}
+	}
+	return pairs
+}
+
+// Returns the lowest unmapped index inside the terminal set, or any unmapped index when terminal is nil
+func (m *vf2Matcher) firstUnmapped(core, terminal []int) int {
+	for n, c := range core {
+		if c == -1 && (terminal == nil || terminal[n] > 0) {
+			return n
+		}
+	}
+	return -1
+}
+
+// Returns true if mapping graph node n1 to pattern node n2 keeps the mapping consistent
+// and the terminal sets still leave room to finish it
+func (m *vf2Matcher) feasible(n1, n2 int) bool {
+	if m.nodeMatch != nil && !m.nodeMatch(n1, n2) {
+		return false
+	}
+	// Self loops
+	w1, loop1 := m.g1.succ[n1][n1]
+	w2, loop2 := m.g2.succ[n2][n2]
+	if loop2 && (!loop1 || (m.edgeMatch != nil && !m.edgeMatch(w1, w2))) {
+		return false
+	}
+	if loop1 && !loop2 && m.mode != vf2Monomorphism {
+		return false
+	}
+
+	directions := [][2][]map[int]float64{{m.g1.succ, m.g2.succ}}
+	if m.directed {
+		directions = append(directions, [2][]map[int]float64{m.g1.pred, m.g2.pred})
+	}
+	for _, d := range directions {
+		adj1, adj2 := d[0], d[1]
+		// Every pattern edge to a mapped node needs a matching graph edge
+		for p2, weight2 := range adj2[n2] {
+			if p2 == n2 || m.core2[p2] == -1 {
+				continue
+			}
+			weight1, exists := adj1[n1][m.core2[p2]]
+			if !exists || (m.edgeMatch != nil && !m.edgeMatch(weight1, weight2)) {
+				return false
+			}
+		}
+		// And the other way round unless the graph may have extra edges
+		if m.mode != vf2Monomorphism {
+			for p1 := range adj1[n1] {
+				if p1 == n1 || m.core1[p1] == -1 {
+					continue
+				}
+				if _, exists := adj2[n2][m.core1[p1]]; !exists {
+					return false
+				}
+			}
+		}
+	}
+	if m.mode == vf2Monomorphism {
+		return true
+	}
+
+	// Look ahead: count unmapped neighbors in each terminal set and outside all of them
+	for _, d := range directions {
+		c1 := m.terminalCounts(d[0][n1], m.core1, m.in1, m.out1)
+		c2 := m.terminalCounts(d[1][n2], m.core2, m.in2, m.out2)
+		for i := range c1 {
+			if (m.mode == vf2Isomorphism && c1[i] != c2[i]) || c1[i] < c2[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns how many unmapped neighbors are in the incoming set, the outgoing set, and neither
+func (m *vf2Matcher) terminalCounts(neighbors map[int]float64, core, in, out []int) [3]int {
+	var counts [3]int
+	for v := range neighbors {
+		if core[v] != -1 {
+			continue
+		}
+		if in[v] > 0 {
+			counts[0]++
+		}
+		if out[v] > 0 {
+			counts[1]++
+		}
+		if in[v] == 0 && out[v] == 0 {
+			counts[2]++
+		}
+	}
+	return counts
+}
+
+// Maps n1 to n2 and grows the terminal sets, returns the graph and pattern nodes whose sets changed
+func (m *vf2Matcher) push(n1, n2 int) [2][]int {
+	m.depth++
+	m.core1[n1], m.core2[n2] = n2, n1
+	return [2][]int{
+		m.grow(n1, m.g1, m.in1, m.out1, m.core1),
+		m.grow(n2, m.g2, m.in2, m.out2, m.core2),
+	}
+}
+
+// Adds n and its unmapped neighbors to the terminal sets at the current depth
+func (m *vf2Matcher) grow(n int, g *vf2Graph, in, out, core []int) []int {
+	changed := make([]int, 0)
+	mark := func(set []int, v int) {
+		if set[v] == 0 {
+			set[v] = m.depth
+			changed = append(changed, v)
+		}
+	}
+	mark(in, n)
+	mark(out, n)
+	for v := range g.succ[n] {
+		if core[v] == -1 {
+			mark(out, v)
+		}
+	}
+	for v := range g.pred[n] {
+		if core[v] == -1 {
+			mark(in, v)
+		}
+	}
+	return changed
+}
+
+// Undoes push
+func (m *vf2Matcher) pop(n1, n2 int, changed [2][]int) {
+	for _, v := range changed[0] {
+		if m.in1[v] == m.depth {
+			m.in1[v] = 0
+		}
+		if m.out1[v] == m.depth {
+			m.out1[v] = 0
+		}
+	}
+	for _, v := range changed[1] {
+		if m.in2[v] == m.depth {
+			m.in2[v] = 0
+		}
+		if m.out2[v] == m.depth {
+			m.out2[v] = 0
+		}
+	}
+	m.core1[n1], m.core2[n2] = -1, -1
+	m.depth--
+}
+
+// Returns true if g1 and g2 can't be isomorphic because their sizes or degree sequences differ
+func vf2Mismatch(g1, g2 *vf2Graph) bool {
+	if len(g1.succ) != len(g2.succ) {
+		return true
+	}
+	degrees := func(g *vf2Graph) [][2]int {
+		d := make([][2]int, len(g.succ))
+		for i := range g.succ {
+			d[i] = [2]int{len(g.succ[i]), len(g.pred[i])}
+		}
+		slices.SortFunc(d, func(a, b [2]int) int {
+			if a[0] != b[0] {
+				return a[0] - b[0]
+			}
+			return a[1] - b[1]
+		})
+		return d
+	}
+	return !slices.Equal(degrees(g1), degrees(g2))
+}
+
+// Returns the mappings of a VF2 search of pattern into g, from pattern keys to g keys
+func vf2Search[K1, K2 comparable, V1, V2 any](g *Graph[K1, V1], pattern *Graph[K2, V2], mode vf2Mode, opts MatchOptions[V1, V2]) []map[K2]K1 {
+	mappings := make([]map[K2]K1, 0)
+	if g.IsDirected != pattern.IsDirected || len(pattern.Nodes) > len(g.Nodes) {
+		return mappings
+	}
+	keys1, g1 := newVF2Graph(g)
+	keys2, g2 := newVF2Graph(pattern)
+	if mode == vf2Isomorphism && vf2Mismatch(g1, g2) {
+		return mappings
+	}
+	m := newVF2Matcher(g1, g2, g.IsDirected, mode)
+	m.limit = opts.Limit
+	m.edgeMatch = opts.EdgeMatch
+	if opts.NodeMatch != nil {
+		m.nodeMatch = func(n1, n2 int) bool {
+			return opts.NodeMatch(g.Nodes[keys1[n1]].Value, pattern.Nodes[keys2[n2]].Value)
+		}
+	}
+	m.match()
+	for _, core := range m.results {
+		mapping := make(map[K2]K1, len(core))
+		for n2, n1 := range core {
+			mapping[keys2[n2]] = keys1[n1]
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
+
+// Returns every isomorphism from g1 to g2 found with VF2, each mapping every node of g1 to a node of g2
+// so that two nodes are joined in g1 exactly when their images are joined in g2
+// opts can also require node values and edge weights to match, and cap the number of mappings
+// Graphs that differ in direction, size or degrees have none
+// Examples
+// a := New[string, int]("a", false)
+// a.AddNode("x", 0)
+// a.AddNode("y", 0)
+// a.AddEdge("x", "y", 1)
+// b := New[int, int]("b", false)
+// b.AddNode(1, 0)
+// b.AddNode(2, 0)
+// b.AddEdge(1, 2, 1)
+// mappings := Isomorphisms(a, b, MatchOptions[int, int]{})
+// fmt.Println(len(mappings)) // Output: 2
+func Isomorphisms[K1, K2 comparable, V1, V2 any](g1 *Graph[K1, V1], g2 *Graph[K2, V2], opts MatchOptions[V1, V2]) []map[K1]K2 {
+	// Search g1 as the pattern inside g2, the mapping then already runs from g1 to g2
+	flipped := MatchOptions[V2, V1]{Limit: opts.Limit, EdgeMatch: opts.EdgeMatch}
+	if opts.EdgeMatch != nil {
+		flipped.EdgeMatch = func(a, b float64) bool { return opts.EdgeMatch(b, a) }
+	}
+	if opts.NodeMatch != nil {
+		flipped.NodeMatch = func(a V2, b V1) bool { return opts.NodeMatch(b, a) }
+	}
+	return vf2Search(g2, g1, vf2Isomorphism, flipped)
+}
+
+// Returns an isomorphism from g1 to g2 if there is one
+// Examples
+// mapping, ok := Isomorphic(a, b, MatchOptions[int, int]{NodeMatch: func(x, y int) bool { return x == y }})
+func Isomorphic[K1, K2 comparable, V1, V2 any](g1 *Graph[K1, V1], g2 *Graph[K2, V2], opts MatchOptions[V1, V2]) (map[K1]K2, bool) {
+	opts.Limit = 1
+	mappings := Isomorphisms(g1, g2, opts)
+	if len(mappings) == 0 {
+		return nil, false
+	}
+	return mappings[0], true
+}
+
+// Returns every placement of pattern inside g found with VF2, each mapping the pattern nodes to distinct nodes of g
+// Every pattern edge must map to an edge of g; with opts.Induced set, unjoined pattern nodes must also map
+// to unjoined nodes, so the matched nodes of g hold exactly the pattern's edges
+// NodeMatch and EdgeMatch get the value or weight from g first and the pattern's second
+// A symmetric pattern is reported once per automorphism, such as 6 times for a triangle
+// Examples
+// triangle := New[string, int]("triangle", false)
+// triangle.AddNode("a", 0)
+// triangle.AddNode("b", 0)
+// triangle.AddNode("c", 0)
+// triangle.AddEdge("a", "b", 1)
+// triangle.AddEdge("b", "c", 1)
+// triangle.AddEdge("c", "a", 1)
+// matches := SubgraphIsomorphisms(layout, triangle, MatchOptions[float64, int]{Induced: true})
+func SubgraphIsomorphisms[K1, K2 comparable, V1, V2 any](g *Graph[K1, V1], pattern *Graph[K2, V2], opts MatchOptions[V1, V2]) []map[K2]K1 {
+	mode := vf2Monomorphism
+	if opts.Induced {
+		mode = vf2Induced
+	}
+	return vf2Search(g, pattern, mode, opts)
+}
@@ -0,0 +1,195 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// Returns true if mapping keeps every edge of pattern, and when induced every non edge too
+func isEmbedding(g, pattern *Graph[int, int], mapping map[int]int, induced bool) bool {
+	used := make(map[int]bool)
+	for _, v := range mapping {
+		if used[v] {
+			return false
+		}
+		used[v] = true
+	}
+	for a := range pattern.Nodes {
+		for b := range pattern.Nodes {
+			if pattern.ContainsEdge(a, b) && !g.ContainsEdge(mapping[a], mapping[b]) {
+				return false
+			}
+			if induced && !pattern.ContainsEdge(a, b) && g.ContainsEdge(mapping[a], mapping[b]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns the number of injective maps from pattern nodes 0..p-1 into g that are embeddings
+func bruteForceEmbeddings(g, pattern *Graph[int, int], induced bool) int {
+	mapping := make(map[int]int)
+	used := make([]bool, len(g.Nodes))
+	var count func(next int) int
+	count = func(next int) int {
+		if next == len(pattern.Nodes) {
+			if isEmbedding(g, pattern, mapping, induced) {
+				return 1
+			}
+			return 0
+		}
+		total := 0
+		for v := range used {
+			if !used[v] {
+				used[v] = true
+				mapping[next] = v
+				total += count(next + 1)
+				used[v] = false
+			}
+		}
+		return total
+	}
+	return count(0)
+}
+
+// Returns g with node i renamed to a string key at position perm[i]
+func relabel(g *Graph[int, int], perm []int) *Graph[string, int] {
+	h := New[string, int]("relabeled", g.IsDirected)
+	for k, n := range g.Nodes {
+		h.AddNode(fmt.Sprint("n", perm[k]), n.Value)
+	}
+	for u, edges := range g.Edges {
+		for v, w := range edges {
+			h.AddEdge(fmt.Sprint("n", perm[u]), fmt.Sprint("n", perm[v]), w)
+		}
+	}
+	return h
+}
+
+func TestIsomorphisms(t *testing.T) {
+	cycle := newUndirectedGraph(5, cycleEdges(5))
+	if got := len(Isomorphisms(cycle, cycle, MatchOptions[int, int]{})); got != 10 {
+		t.Errorf("Found %d automorphisms of a 5 cycle, expected 10", got)
+	}
+	path := newUndirectedGraph(5, cycleEdges(5)[:4])
+	if _, ok := Isomorphic(cycle, path, MatchOptions[int, int]{}); ok {
+		t.Error("A cycle and a path can't be isomorphic")
+	}
+	// Two triangles and a 6 cycle share their degrees but not their shape
+	triangles := newUndirectedGraph(6, [][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}})
+	if _, ok := Isomorphic(triangles, newUndirectedGraph(6, cycleEdges(6)), MatchOptions[int, int]{}); ok {
+		t.Error("Two triangles and a 6 cycle can't be isomorphic")
+	}
+
+	rng := rand.New(rand.NewSource(41))
+	for round := 0; round < 20; round++ {
+		directed := round%2 == 1
+		g := randomGraph{n: 6, p: 0.4, directed: directed}.build(rng)
+		h := relabel(g, rng.Perm(6))
+		mapping, ok := Isomorphic(g, h, MatchOptions[int, int]{})
+		if !ok {
+			t.Fatalf("Expected a relabeled graph to be isomorphic")
+		}
+		for u, edges := range g.Edges {
+			for v := range edges {
+				if !h.ContainsEdge(mapping[u], mapping[v]) {
+					t.Fatalf("Mapping %v loses the edge %d-%d", mapping, u, v)
+				}
+			}
+		}
+		want := bruteForceEmbeddings(g, g, true)
+		if got := len(Isomorphisms(g, h, MatchOptions[int, int]{})); got != want {
+			t.Errorf("Found %d isomorphisms, expected %d", got, want)
+		}
+		if got := len(Isomorphisms(g, h, MatchOptions[int, int]{Limit: 1})); got != 1 {
+			t.Errorf("Expected the limit to stop at 1 mapping, got %d", got)
+		}
+	}
+}
+
+func TestIsomorphismsDirected(t *testing.T) {
+	// a→b→c and a→b←c share their underlying graph
+	chain := New[string, int]("chain", true)
+	sink := New[string, int]("sink", true)
+	for _, k := range []string{"a", "b", "c"} {
+		chain.AddNode(k, 0)
+		sink.AddNode(k, 0)
+	}
+	chain.AddEdge("a", "b", 1)
+	chain.AddEdge("b", "c", 1)
+	sink.AddEdge("a", "b", 1)
+	sink.AddEdge("c", "b", 1)
+	if _, ok := Isomorphic(chain, sink, MatchOptions[int, int]{}); ok {
+		t.Error("Expected edge directions to matter")
+	}
+	undirected := New[string, int]("undirected", false)
+	for _, k := range []string{"a", "b", "c"} {
+		undirected.AddNode(k, 0)
+	}
+	undirected.AddEdge("a", "b", 1)
+	undirected.AddEdge("b", "c", 1)
+	if _, ok := Isomorphic(chain, undirected, MatchOptions[int, int]{}); ok {
+		t.Error("Expected a directed and an undirected graph to never match")
+	}
+}
+
+func TestIsomorphismsMatchers(t *testing.T) {
+	// Rooms 0-1-2 with values 5 7 5, the hallway 0-1 twice as long
+	a := newUndirectedGraph(3, [][2]int{{0, 1}, {1, 2}})
+	a.Nodes[0].Value, a.Nodes[1].Value, a.Nodes[2].Value = 5, 7, 5
+	a.AddEdge(0, 1, 2)
+	b := relabel(a, []int{2, 0, 1})
+
+	values := MatchOptions[int, int]{NodeMatch: func(x, y int) bool { return x == y }}
+	if got := len(Isomorphisms(a, b, values)); got != 2 {
+		t.Errorf("Found %d value preserving isomorphisms, expected 2", got)
+	}
+	values.EdgeMatch = func(x, y float64) bool { return x == y }
+	mapping, ok := Isomorphic(a, b, values)
+	if !ok || mapping[0] != "n2" || mapping[1] != "n0" || mapping[2] != "n1" {
+		t.Errorf("Expected the weights to pin the mapping, got %v", mapping)
+	}
+	b.Nodes["n1"].Value = 6
+	if _, ok := Isomorphic(a, b, values); ok {
+		t.Error("Expected differing values to prevent a match")
+	}
+}
+
+func TestSubgraphIsomorphisms(t *testing.T) {
+	triangle := newUndirectedGraph(3, cycleEdges(3))
+	k4 := newUndirectedGraph(4, [][2]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}})
+	if got := len(SubgraphIsomorphisms(k4, triangle, MatchOptions[int, int]{})); got != 24 {
+		t.Errorf("Found %d triangles in K4, expected 24 mappings", got)
+	}
+	path := newUndirectedGraph(3, [][2]int{{0, 1}, {1, 2}})
+	if got := len(SubgraphIsomorphisms(triangle, path, MatchOptions[int, int]{})); got != 6 {
+		t.Errorf("Found %d paths in a triangle, expected 6", got)
+	}
+	if got := len(SubgraphIsomorphisms(triangle, path, MatchOptions[int, int]{Induced: true})); got != 0 {
+		t.Errorf("Found %d induced paths in a triangle, expected none", got)
+	}
+	if got := len(SubgraphIsomorphisms(path, triangle, MatchOptions[int, int]{})); got != 0 {
+		t.Errorf("Expected no triangle in a path, found %d", got)
+	}
+
+	rng := rand.New(rand.NewSource(43))
+	for round := 0; round < 20; round++ {
+		directed := round%2 == 1
+		g := randomGraph{n: 7, p: 0.5, directed: directed}.build(rng)
+		pattern := randomGraph{n: 4, p: 0.4, directed: directed}.build(rng)
+		for _, induced := range []bool{false, true} {
+			opts := MatchOptions[int, int]{Induced: induced}
+			mappings := SubgraphIsomorphisms(g, pattern, opts)
+			if want := bruteForceEmbeddings(g, pattern, induced); len(mappings) != want {
+				t.Errorf("Found %d embeddings (induced %v), expected %d", len(mappings), induced, want)
+			}
+			for _, mapping := range mappings {
+				if !isEmbedding(g, pattern, mapping, induced) {
+					t.Fatalf("Mapping %v is not an embedding", mapping)
+				}
+			}
+		}
+	}
+}
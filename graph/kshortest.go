@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// WeightedPath is a path through the graph together with its total edge weight
+type WeightedPath[K comparable] struct {
+	Path []K
+	Cost float64
+}
+
+// Graph view with some nodes and edges hidden, so Dijkstra can search around them
+type filteredGraph[K comparable, V any] struct {
+	g            *Graph[K, V]
+	removedNodes map[K]bool
+	removedEdges map[[2]K]bool
+}
+
+func (f *filteredGraph[K, V]) Neighbors(k K) []Successor[K] {
+	neighbors := make([]Successor[K], 0, len(f.g.Edges[k]))
+	for neighbor, weight := range f.g.Edges[k] {
+		if f.removedNodes[neighbor] || f.removedEdges[[2]K{k, neighbor}] {
+			continue
+		}
+		neighbors = append(neighbors, Successor[K]{Key: neighbor, Cost: weight})
+	}
+	return neighbors
+}
+
+func (f *filteredGraph[K, V]) ContainsNode(k K) bool {
+	return f.g.ContainsNode(k) && !f.removedNodes[k]
+}
+
+// Hides the edge from k1 to k2, and from k2 to k1 if the graph is undirected
+func (f *filteredGraph[K, V]) removeEdge(k1, k2 K) {
+	f.removedEdges[[2]K{k1, k2}] = true
+	if !f.g.IsDirected {
+		f.removedEdges[[2]K{k2, k1}] = true
+	}
+}
+
+// Returns up to k loopless paths from start to end in increasing cost order using Yen's algorithm
+// The first path is the one Dijkstra finds, each later path branches off an earlier one
+// at a spur node and is found by Dijkstra with the already used edges hidden
+// In an undirected graph an edge is hidden in both directions, matching ContainsEdge
+// Fewer than k paths are returned if the graph doesn't have that many
+// Examples
+// g := New[string, int]("MyGraph", true)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 1)
+// g.AddEdge("A", "C", 3)
+// paths, err := g.KShortestPaths("A", "C", 2)
+// fmt.Println(paths) // Output: [{[A B C] 2} {[A C] 3}]
+func (g *Graph[K, V]) KShortestPaths(start, end K, k int) ([]WeightedPath[K], error) {
+	if k <= 0 {
+		return nil, errors.New("k must be positive")
+	}
+	first, err := g.DijkstraResult(start, end)
+	if err != nil {
+		return nil, err
+	}
+	found := []WeightedPath[K]{{Path: first.Path, Cost: first.Cost}}
+	candidates := make([]WeightedPath[K], 0)
+	ctx := context.Background()
+
+	for len(found) < k {
+		previous := found[len(found)-1].Path
+		for i := 0; i < len(previous)-1; i++ {
+			spur := previous[i]
+			root := previous[:i+1]
+			view := &filteredGraph[K, V]{
+				g:            g,
+				removedNodes: make(map[K]bool),
+				removedEdges: make(map[[2]K]bool),
+			}
+			// Hide the next edge of every found path that shares this root
+			for _, p := range found {
+				if len(p.Path) > i+1 && slices.Equal(p.Path[:i+1], root) {
+					view.removeEdge(p.Path[i], p.Path[i+1])
+				}
+			}
+			// Hide the root so the spur path can't loop back through it
+			for _, node := range root[:i] {
+				view.removedNodes[node] = true
+			}
+
+			spurResult, err := SearchDijkstra(ctx, view, spur, end, SearchLimits{})
+			if err != nil {
+				continue
+			}
+			path := append(slices.Clone(root), spurResult.Path[1:]...)
+			if containsPath(found, path) || containsPath(candidates, path) {
+				continue
+			}
+			candidates = append(candidates, WeightedPath[K]{Path: path, Cost: g.pathCost(root) + spurResult.Cost})
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		best := 0
+		for i, c := range candidates {
+			if c.Cost < candidates[best].Cost {
+				best = i
+			}
+		}
+		found = append(found, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+	return found, nil
+}
+
+// Returns true if path is already one of paths
+func containsPath[K comparable](paths []WeightedPath[K], path []K) bool {
+	for _, p := range paths {
+		if slices.Equal(p.Path, path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestKShortestPaths(t *testing.T) {
+	g := New[string, int]("yen", true)
+	for _, k := range []string{"C", "D", "E", "F", "G", "H"} {
+		g.AddNode(k, 0)
+	}
+	g.AddEdge("C", "D", 3)
+	g.AddEdge("C", "E", 2)
+	g.AddEdge("D", "F", 4)
+	g.AddEdge("E", "D", 1)
+	g.AddEdge("E", "F", 2)
+	g.AddEdge("E", "G", 3)
+	g.AddEdge("F", "G", 2)
+	g.AddEdge("F", "H", 1)
+	g.AddEdge("G", "H", 2)
+
+	paths, err := g.KShortestPaths("C", "H", 10)
+	if err != nil {
+		t.Fatalf("KShortestPaths failed: %v", err)
+	}
+	if !reflect.DeepEqual(paths[0].Path, []string{"C", "E", "F", "H"}) || paths[0].Cost != 5 {
+		t.Errorf("First path %v, expected [C E F H] at cost 5", paths[0])
+	}
+	if !reflect.DeepEqual(paths[1].Path, []string{"C", "E", "G", "H"}) || paths[1].Cost != 7 {
+		t.Errorf("Second path %v, expected [C E G H] at cost 7", paths[1])
+	}
+	// Every loopless C to H path in this graph
+	if len(paths) != 7 {
+		t.Errorf("Expected 7 paths, got %d: %v", len(paths), paths)
+	}
+	for i := 1; i < len(paths); i++ {
+		if paths[i].Cost < paths[i-1].Cost {
+			t.Errorf("Paths out of order: %v", paths)
+		}
+		if paths[i].Cost != g.pathCost(paths[i].Path) {
+			t.Errorf("Path %v does not cost %f", paths[i].Path, paths[i].Cost)
+		}
+	}
+}
+
+func TestKShortestPathsUndirected(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	g := randomGraph{n: 15, p: 0.28, weight: func(rng *rand.Rand) float64 {
+		return float64(1 + rng.Intn(5))
+	}}.build(rng)
+	paths, err := g.KShortestPaths(0, 14, 20)
+	if err != nil {
+		t.Fatalf("KShortestPaths failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	last := math.Inf(-1)
+	for _, p := range paths {
+		onPath := make(map[int]bool)
+		for i, k := range p.Path {
+			if onPath[k] {
+				t.Errorf("Path %v has a loop", p.Path)
+			}
+			onPath[k] = true
+			if i > 0 && !g.ContainsEdge(p.Path[i-1], k) {
+				t.Errorf("Path %v uses a missing edge", p.Path)
+			}
+		}
+		key := fmt.Sprint(p.Path)
+		if seen[key] {
+			t.Errorf("Path %v returned twice", p.Path)
+		}
+		seen[key] = true
+		if p.Cost < last {
+			t.Errorf("Paths out of order: %v", paths)
+		}
+		last = p.Cost
+	}
+}
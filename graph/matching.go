@@ -0,0 +1,251 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Matching is a set of pairs where no node appears twice
+// Each pair is an edge From a left node To a right node, Cost is the sum of the pair weights
+type Matching[K comparable] struct {
+	Pairs []Edge[K]
+	Cost  float64
+}
+
+// Returns a maximum cardinality matching of a bipartite graph using the Hopcroft-Karp algorithm
+// left lists the nodes of one partition, every other node is in the right partition
+// Only edges leaving left nodes are used, so in a directed graph edges must point from left to right
+// Each phase finds a maximal set of shortest augmenting paths, O(E sqrt(V)) overall
+// Returns an error if a left node is missing or an edge joins two left nodes
+// Examples
+// g := New[string, int]("jobs", false)
+// g.AddNode("alice", 0)
+// g.AddNode("bob", 0)
+// g.AddNode("cook", 0)
+// g.AddNode("drive", 0)
+// g.AddEdge("alice", "cook", 1)
+// g.AddEdge("alice", "drive", 1)
+// g.AddEdge("bob", "cook", 1)
+// m, err := g.HopcroftKarp([]string{"alice", "bob"})
+// fmt.Println(m.Pairs) // Output: [{alice drive 1} {bob cook 1}]
+func (g *Graph[K, V]) HopcroftKarp(left []K) (*Matching[K], error) {
+	leftIndex := make(map[K]int, len(left))
+	for i, k := range left {
+		if !g.ContainsNode(k) {
+			return nil, fmt.Errorf("left node %v not in graph", k)
+		}
+		leftIndex[k] = i
+	}
+	right := make([]K, 0)
+	rightIndex := make(map[K]int)
+	adjacency := make([][]int, len(left))
+	for i, u := range left {
+		for v := range g.Edges[u] {
+			if _, isLeft := leftIndex[v]; isLeft {
+				return nil, fmt.Errorf("edge %v-%v joins two left nodes", u, v)
+			}
+			if _, exists := rightIndex[v]; !exists {
+				rightIndex[v] = len(right)
+				right = append(right, v)
+			}
+			adjacency[i] = append(adjacency[i], rightIndex[v])
+		}
+	}
+
+	// -1 means unmatched
+	matchLeft := make([]int, len(left))
+	matchRight := make([]int, len(right))
+	for i := range matchLeft {
+		matchLeft[i] = -1
+	}
+	for i := range matchRight {
+		matchRight[i] = -1
+	}
+	layer := make([]int, len(left))
+	// Layer of the left nodes that reach a free right node, the length of the shortest augmenting paths
+	freeLayer := -1
+
+	// Layers free left nodes at 0 and alternates along unmatched then matched edges,
+	// stopping after the first layer that reaches a free right node
+	// Returns true if some free right node was reached
+	buildLayers := func() bool {
+		queue := make([]int, 0)
+		for u := range left {
+			if matchLeft[u] == -1 {
+				layer[u] = 0
+				queue = append(queue, u)
+			} else {
+				layer[u] = -1
+			}
+		}
+		freeLayer = -1
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			if freeLayer != -1 && layer[u] > freeLayer {
+				break
+			}
+			for _, v := range adjacency[u] {
+				next := matchRight[v]
+				if next == -1 {
+					freeLayer = layer[u]
+				} else if layer[next] == -1 && freeLayer == -1 {
+					layer[next] = layer[u] + 1
+					queue = append(queue, next)
+				}
+			}
+		}
+		return freeLayer != -1
+	}
+
+	// Only paths ending at the free layer are shortest, longer ones wait for a later phase
+	var augment func(u int) bool
+	augment = func(u int) bool {
+		for _, v := range adjacency[u] {
+			next := matchRight[v]
+			if (next == -1 && layer[u] == freeLayer) ||
+				(next != -1 && layer[next] == layer[u]+1 && layer[u] < freeLayer && augment(next)) {
+				matchLeft[u] = v
+				matchRight[v] = u
+				return true
+			}
+		}
+		// Dead end for the rest of this phase
+		layer[u] = -1
+		return false
+	}
+
+	for buildLayers() {
+		for u := range left {
+			if matchLeft[u] == -1 {
+				augment(u)
+			}
+		}
+	}
+
+	m := &Matching[K]{Pairs: make([]Edge[K], 0)}
+	for u, v := range matchLeft {
+		if v == -1 {
+			continue
+		}
+		weight := g.Edges[left[u]][right[v]]
+		m.Pairs = append(m.Pairs, Edge[K]{From: left[u], To: right[v], Weight: weight})
+		m.Cost += weight
+	}
+	return m, nil
+}
+
+// Returns the cheapest assignment of rows to columns of a cost matrix using the Hungarian (Kuhn-Munkres) algorithm
+// Every row is matched to a different column, so there must be at least as many columns as rows;
+// with more rows than columns every column is matched to a different row instead
+// Each pair is an edge From the row index To the column index weighted by its cost
+// Runs in O(n^2 m) time for n rows and m columns
+// Examples
+//
+//	costs := [][]float64{
+//		{4, 1, 3},
+//		{2, 0, 5},
+//		{3, 2, 2},
+//	}
+//
+// m, err := Hungarian(costs)
+// fmt.Println(m.Pairs, m.Cost) // Output: [{0 1 1} {1 0 2} {2 2 2}] 5
+func Hungarian(costs [][]float64) (*Matching[int], error) {
+	rows := len(costs)
+	if rows == 0 {
+		return &Matching[int]{Pairs: make([]Edge[int], 0)}, nil
+	}
+	cols := len(costs[0])
+	for _, row := range costs {
+		if len(row) != cols {
+			return nil, errors.New("cost matrix rows must all have the same length")
+		}
+		for _, c := range row {
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				return nil, errors.New("costs must be finite")
+			}
+		}
+	}
+	if rows > cols {
+		transposed := make([][]float64, cols)
+		for j := range transposed {
+			transposed[j] = make([]float64, rows)
+			for i := range costs {
+				transposed[j][i] = costs[i][j]
+			}
+		}
+		m, err := Hungarian(transposed)
+		if err != nil {
+			return nil, err
+		}
+		for i := range m.Pairs {
+			m.Pairs[i].From, m.Pairs[i].To = m.Pairs[i].To, m.Pairs[i].From
+		}
+		return m, nil
+	}
+
+	// Potentials u for rows and v for columns, with 1-based indices and column 0 as a sentinel
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	// rowOf[j] is the row assigned to column j, 0 if none
+	rowOf := make([]int, cols+1)
+	way := make([]int, cols+1)
+	for i := 1; i <= rows; i++ {
+		rowOf[0] = i
+		j0 := 0
+		minSlack := make([]float64, cols+1)
+		used := make([]bool, cols+1)
+		for j := range minSlack {
+			minSlack[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := rowOf[j0], math.Inf(1), 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				if slack := costs[i0-1][j-1] - u[i0] - v[j]; slack < minSlack[j] {
+					minSlack[j] = slack
+					way[j] = j0
+				}
+				if minSlack[j] < delta {
+					delta = minSlack[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[rowOf[j]] += delta
+					v[j] -= delta
+				} else {
+					minSlack[j] -= delta
+				}
+			}
+			j0 = j1
+			if rowOf[j0] == 0 {
+				break
+			}
+		}
+		// Flip the alternating path back to the sentinel
+		for j0 != 0 {
+			j1 := way[j0]
+			rowOf[j0] = rowOf[j1]
+			j0 = j1
+		}
+	}
+
+	m := &Matching[int]{Pairs: make([]Edge[int], 0, rows)}
+	colOf := make([]int, rows)
+	for j := 1; j <= cols; j++ {
+		if rowOf[j] != 0 {
+			colOf[rowOf[j]-1] = j - 1
+		}
+	}
+	for i, j := range colOf {
+		m.Pairs = append(m.Pairs, Edge[int]{From: i, To: j, Weight: costs[i][j]})
+		m.Cost += costs[i][j]
+	}
+	return m, nil
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestHopcroftKarp(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	for round := 0; round < 10; round++ {
+		g := New[string, int]("bipartite", false)
+		left := make([]string, 0)
+		for i := 0; i < 30; i++ {
+			left = append(left, fmt.Sprint("agent", i))
+			g.AddNode(left[i], 0)
+			g.AddNode(fmt.Sprint("task", i), 0)
+		}
+		g.AddNode("source", 0)
+		g.AddNode("sink", 0)
+		for i := 0; i < 60; i++ {
+			g.AddEdge(fmt.Sprint("agent", rng.Intn(30)), fmt.Sprint("task", rng.Intn(30)), 1)
+		}
+		m, err := g.HopcroftKarp(left)
+		if err != nil {
+			t.Fatalf("HopcroftKarp failed: %v", err)
+		}
+		used := make(map[string]bool)
+		for _, p := range m.Pairs {
+			if used[p.From] || used[p.To] || !g.ContainsEdge(p.From, p.To) {
+				t.Fatalf("Invalid matching %v", m.Pairs)
+			}
+			used[p.From], used[p.To] = true, true
+		}
+
+		// The maximum matching size equals the max flow of the unit capacity network
+		flow := New[string, int]("flow", true)
+		for k := range g.Nodes {
+			flow.AddNode(k, 0)
+		}
+		for _, u := range left {
+			flow.AddEdge("source", u, 1)
+			for v := range g.Edges[u] {
+				flow.AddEdge(u, v, 1)
+				flow.AddEdge(v, "sink", 1)
+			}
+		}
+		res, err := flow.MaxFlow("source", "sink")
+		if err != nil {
+			t.Fatalf("MaxFlow failed: %v", err)
+		}
+		if float64(len(m.Pairs)) != res.Value || m.Cost != res.Value {
+			t.Errorf("HopcroftKarp matched %d pairs, max flow is %f", len(m.Pairs), res.Value)
+		}
+	}
+
+	g := New[string, int]("not bipartite", false)
+	g.AddNode("a", 0)
+	g.AddNode("b", 0)
+	g.AddEdge("a", "b", 1)
+	if _, err := g.HopcroftKarp([]string{"a", "b"}); err == nil {
+		t.Error("Expected HopcroftKarp to reject an edge between two left nodes")
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	rng := rand.New(rand.NewSource(14))
+	for round := 0; round < 20; round++ {
+		size := 1 + rng.Intn(6)
+		costs := make([][]float64, size)
+		for i := range costs {
+			costs[i] = make([]float64, size)
+			for j := range costs[i] {
+				costs[i][j] = float64(rng.Intn(50) - 10)
+			}
+		}
+		m, err := Hungarian(costs)
+		if err != nil {
+			t.Fatalf("Hungarian failed: %v", err)
+		}
+		if want := bruteForceAssignment(costs); m.Cost != want {
+			t.Errorf("Hungarian cost %f, brute force %f for %v", m.Cost, want, costs)
+		}
+		if len(m.Pairs) != size {
+			t.Errorf("Hungarian matched %d pairs, expected %d", len(m.Pairs), size)
+		}
+	}
+
+	// Two rows and three columns, and the transpose
+	costs := [][]float64{
+		{7, 1, 3},
+		{2, 9, 1},
+	}
+	m, err := Hungarian(costs)
+	if err != nil || m.Cost != 2 {
+		t.Errorf("Expected cost 2, got %v, %v", m, err)
+	}
+	m, err = Hungarian([][]float64{{7, 2}, {1, 9}, {3, 1}})
+	if err != nil || m.Cost != 2 || len(m.Pairs) != 2 {
+		t.Errorf("Expected 2 pairs at cost 2, got %v, %v", m, err)
+	}
+	for _, p := range m.Pairs {
+		if p.From > 2 || p.To > 1 {
+			t.Errorf("Pair %v is outside the 3x2 matrix", p)
+		}
+	}
+}
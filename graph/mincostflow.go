@@ -0,0 +1,265 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"main.go/helpers"
+)
+
+// FlowEdge is a directed edge of a FlowNetwork
+// Capacity is the most flow it can carry and Cost is paid per unit of flow
+type FlowEdge[K comparable] struct {
+	From     K
+	To       K
+	Capacity float64
+	Cost     float64
+}
+
+// FlowNetwork is a directed network over node keys where every edge has both a capacity and a cost
+// Supply holds how much flow a node produces, negative values are demands
+// Parallel edges are allowed, which a Graph's single weight per edge can't express
+type FlowNetwork[K comparable] struct {
+	Nodes  map[K]bool
+	Edges  []FlowEdge[K]
+	Supply map[K]float64
+}
+
+// MinCostFlowResult holds a flow and what it costs
+// Flow[u][v] is the total flow on the edges from u to v, only edges carrying flow are listed
+type MinCostFlowResult[K comparable] struct {
+	Value float64
+	Cost  float64
+	Flow  map[K]map[K]float64
+}
+
+// Returns an empty flow network
+// Examples
+// n := NewFlowNetwork[string]()
+// n.AddEdge("depot", "town", 5, 2)
+// n.SetSupply("depot", 3)
+// n.SetSupply("town", -3)
+// res, err := n.MinCostFlow()
+// fmt.Println(res.Cost) // Output: 6
+func NewFlowNetwork[K comparable]() *FlowNetwork[K] {
+	return &FlowNetwork[K]{
+		Nodes:  make(map[K]bool),
+		Edges:  make([]FlowEdge[K], 0),
+		Supply: make(map[K]float64),
+	}
+}
+
+// Returns a flow network with the nodes of g, using edge weights as capacities
+// cost gives the per-unit cost of each edge, an undirected edge becomes two directed edges
+// Examples
+// n := FlowNetworkFromGraph(g, func(from, to string, weight float64) float64 { return travelTime[from][to] })
+func FlowNetworkFromGraph[K comparable, V any](g *Graph[K, V], cost func(from, to K, weight float64) float64) *FlowNetwork[K] {
+	n := NewFlowNetwork[K]()
+	for k := range g.Nodes {
+		n.AddNode(k)
+	}
+	for u, edges := range g.Edges {
+		for v, weight := range edges {
+			n.AddEdge(u, v, weight, cost(u, v, weight))
+		}
+	}
+	return n
+}
+
+// Adds a node with no supply, does nothing if it already exists
+func (n *FlowNetwork[K]) AddNode(k K) {
+	n.Nodes[k] = true
+}
+
+// Adds a directed edge, adding its end nodes if needed
+func (n *FlowNetwork[K]) AddEdge(from, to K, capacity, cost float64) {
+	n.AddNode(from)
+	n.AddNode(to)
+	n.Edges = append(n.Edges, FlowEdge[K]{From: from, To: to, Capacity: capacity, Cost: cost})
+}
+
+// Sets how much flow k produces, negative amounts are demands
+func (n *FlowNetwork[K]) SetSupply(k K, amount float64) {
+	n.AddNode(k)
+	n.Supply[k] = amount
+}
+
+// Residual network of n with two extra nodes at the end for a super source and super sink
+func (n *FlowNetwork[K]) residual() (*residualNetwork[K], error) {
+	keys := make([]K, 0, len(n.Nodes))
+	for k := range n.Nodes {
+		keys = append(keys, k)
+	}
+	net := newResidualNetwork(keys)
+	net.arcs = append(net.arcs, nil, nil)
+	for _, e := range n.Edges {
+		if e.Capacity < 0 {
+			return nil, errors.New("edge capacities must be non-negative")
+		}
+		net.addArc(net.index[e.From], net.index[e.To], e.Capacity, 0, e.Cost)
+	}
+	return net, nil
+}
+
+// Returns the maximum flow from source to sink that has the lowest total cost
+// Supplies set on the network are ignored
+// Uses successive shortest paths: flow is always sent along the cheapest path left in the residual network
+// Negative costs are allowed as long as no cycle of edges has a negative total cost
+// Examples
+// n := NewFlowNetwork[string]()
+// n.AddEdge("S", "A", 2, 1)
+// n.AddEdge("S", "B", 2, 3)
+// n.AddEdge("A", "T", 1, 1)
+// n.AddEdge("B", "T", 2, 1)
+// res, err := n.MinCostMaxFlow("S", "T")
+// fmt.Println(res.Value, res.Cost) // Output: 3 10
+func (n *FlowNetwork[K]) MinCostMaxFlow(source, sink K) (*MinCostFlowResult[K], error) {
+	if !n.Nodes[source] || !n.Nodes[sink] {
+		return nil, errors.New("source or sink node not in network")
+	}
+	if source == sink {
+		return nil, errors.New("source and sink must be different nodes")
+	}
+	net, err := n.residual()
+	if err != nil {
+		return nil, err
+	}
+	return net.successiveShortestPaths(net.index[source], net.index[sink], math.Inf(1))
+}
+
+// Returns the cheapest flow that moves every node's supply to the nodes with demand
+// Total supply must equal total demand
+// Returns an error if a supply is set on a node that isn't in the network, or if the edges can't carry enough flow to meet every demand
+// Examples
+// n.SetSupply("factory", 10)
+// n.SetSupply("shop1", -4)
+// n.SetSupply("shop2", -6)
+// res, err := n.MinCostFlow()
+func (n *FlowNetwork[K]) MinCostFlow() (*MinCostFlowResult[K], error) {
+	net, err := n.residual()
+	if err != nil {
+		return nil, err
+	}
+	// Supplies hang off a super source and demands off a super sink
+	s, t := len(net.keys), len(net.keys)+1
+	supply, demand := 0.0, 0.0
+	for k, amount := range n.Supply {
+		if !n.Nodes[k] {
+			return nil, fmt.Errorf("supply set on node %v which is not in the network", k)
+		}
+		if amount > 0 {
+			net.addArc(s, net.index[k], amount, 0, 0)
+			supply += amount
+		} else if amount < 0 {
+			net.addArc(net.index[k], t, -amount, 0, 0)
+			demand -= amount
+		}
+	}
+	if math.Abs(supply-demand) > flowEpsilon {
+		return nil, fmt.Errorf("total supply %v does not match total demand %v", supply, demand)
+	}
+	res, err := net.successiveShortestPaths(s, t, supply)
+	if err != nil {
+		return nil, err
+	}
+	if res.Value < supply-flowEpsilon {
+		return res, fmt.Errorf("infeasible: only %v of %v units can be delivered", res.Value, supply)
+	}
+	return res, nil
+}
+
+// Sends up to limit units from s to t along successively cheapest residual paths
+// Node potentials from Bellman-Ford keep the reduced costs non-negative so Dijkstra can find each path
+func (net *residualNetwork[K]) successiveShortestPaths(s, t int, limit float64) (*MinCostFlowResult[K], error) {
+	size := len(net.arcs)
+	potential := make([]float64, size)
+	// Bellman-Ford from a virtual source tied to every node, as in Johnson's algorithm
+	for pass := 0; ; pass++ {
+		relaxed := false
+		for u := range net.arcs {
+			for _, arc := range net.arcs[u] {
+				if arc.capacity > flowEpsilon && potential[u]+arc.cost < potential[arc.to]-flowEpsilon {
+					potential[arc.to] = potential[u] + arc.cost
+					relaxed = true
+				}
+			}
+		}
+		if !relaxed {
+			break
+		}
+		if pass == size {
+			return nil, errors.New("network has a negative cost cycle")
+		}
+	}
+
+	res := &MinCostFlowResult[K]{Flow: make(map[K]map[K]float64)}
+	dist := make([]float64, size)
+	parent := make([]int, size)
+	parentArc := make([]int, size)
+	for res.Value < limit-flowEpsilon {
+		for i := range dist {
+			dist[i] = math.Inf(1)
+			parent[i] = -1
+		}
+		dist[s] = 0
+		pq := make(helpers.PriorityQueue[int], 0)
+		pq.PushItem(s, 0)
+		for pq.Len() > 0 {
+			item := pq[0]
+			u := pq.PopItem()
+			if item.Priority > dist[u] {
+				continue
+			}
+			for i, arc := range net.arcs[u] {
+				if arc.capacity <= flowEpsilon {
+					continue
+				}
+				reduced := arc.cost + potential[u] - potential[arc.to]
+				if newDist := dist[u] + reduced; newDist < dist[arc.to]-flowEpsilon {
+					dist[arc.to] = newDist
+					parent[arc.to] = u
+					parentArc[arc.to] = i
+					pq.PushItem(arc.to, newDist)
+				}
+			}
+		}
+		if math.IsInf(dist[t], 1) {
+			break
+		}
+		for i := range potential {
+			if !math.IsInf(dist[i], 1) {
+				potential[i] += dist[i]
+			}
+		}
+
+		amount := limit - res.Value
+		for v := t; v != s; v = parent[v] {
+			amount = min(amount, net.arcs[parent[v]][parentArc[v]].capacity)
+		}
+		if math.IsInf(amount, 1) {
+			return nil, errors.New("network has an uncapacitated path from source to sink")
+		}
+		for v := t; v != s; v = parent[v] {
+			res.Cost += amount * net.arcs[parent[v]][parentArc[v]].cost
+			net.push(parent[v], parentArc[v], amount)
+		}
+		res.Value += amount
+	}
+
+	for u := range net.keys {
+		for _, arc := range net.arcs[u] {
+			if arc.to >= len(net.keys) {
+				continue
+			}
+			if flow := arc.initial - arc.capacity; arc.initial > 0 && flow > flowEpsilon {
+				from, to := net.keys[u], net.keys[arc.to]
+				if _, exists := res.Flow[from]; !exists {
+					res.Flow[from] = make(map[K]float64)
+				}
+				res.Flow[from][to] += flow
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMinCostMaxFlow(t *testing.T) {
+	n := NewFlowNetwork[string]()
+	n.AddEdge("S", "A", 2, 1)
+	n.AddEdge("S", "B", 2, 3)
+	n.AddEdge("A", "T", 1, 1)
+	n.AddEdge("B", "T", 2, 1)
+	n.AddEdge("A", "B", 1, 5)
+	res, err := n.MinCostMaxFlow("S", "T")
+	if err != nil {
+		t.Fatalf("MinCostMaxFlow failed: %v", err)
+	}
+	// A->T is full after one unit, the second unit is cheaper through S->B than S->A->B
+	if res.Value != 3 || res.Cost != 10 {
+		t.Errorf("MinCostMaxFlow sent %f at cost %f, expected 3 at cost 10", res.Value, res.Cost)
+	}
+	if res.Flow["S"]["B"] != 2 || res.Flow["A"]["B"] != 0 {
+		t.Errorf("Unexpected flow assignment %v", res.Flow)
+	}
+}
+
+// Returns the cheapest assignment of workers to jobs by trying every permutation
+func bruteForceAssignment(costs [][]float64) float64 {
+	best := math.Inf(1)
+	used := make([]bool, len(costs))
+	var try func(row int, total float64)
+	try = func(row int, total float64) {
+		if row == len(costs) {
+			best = min(best, total)
+			return
+		}
+		for col := range costs[row] {
+			if !used[col] {
+				used[col] = true
+				try(row+1, total+costs[row][col])
+				used[col] = false
+			}
+		}
+	}
+	try(0, 0)
+	return best
+}
+
+func TestMinCostFlowAssignment(t *testing.T) {
+	rng := rand.New(rand.NewSource(10))
+	for round := 0; round < 10; round++ {
+		size := 2 + rng.Intn(5)
+		costs := make([][]float64, size)
+		n := NewFlowNetwork[string]()
+		for i := range costs {
+			costs[i] = make([]float64, size)
+			n.SetSupply(fmt.Sprint("unit", i), 1)
+			n.SetSupply(fmt.Sprint("target", i), -1)
+			for j := range costs[i] {
+				costs[i][j] = float64(rng.Intn(20) - 5)
+				n.AddEdge(fmt.Sprint("unit", i), fmt.Sprint("target", j), 1, costs[i][j])
+			}
+		}
+		res, err := n.MinCostFlow()
+		if err != nil {
+			t.Fatalf("MinCostFlow failed: %v", err)
+		}
+		if want := bruteForceAssignment(costs); res.Cost != want {
+			t.Errorf("MinCostFlow cost %f, brute force %f", res.Cost, want)
+		}
+		for i := 0; i < size; i++ {
+			if len(res.Flow[fmt.Sprint("unit", i)]) != 1 {
+				t.Errorf("unit%d is not assigned to exactly one target: %v", i, res.Flow)
+			}
+		}
+	}
+}
+
+func TestMinCostFlowInfeasible(t *testing.T) {
+	n := NewFlowNetwork[string]()
+	n.AddEdge("depot", "town", 2, 1)
+	n.SetSupply("depot", 3)
+	n.SetSupply("town", -3)
+	if _, err := n.MinCostFlow(); err == nil {
+		t.Error("Expected MinCostFlow to fail when edges can't carry the demand")
+	}
+	n.SetSupply("town", -2)
+	if _, err := n.MinCostFlow(); err == nil {
+		t.Error("Expected MinCostFlow to fail when supply and demand differ")
+	}
+	n.Supply["ghost"] = -1
+	if _, err := n.MinCostFlow(); err == nil {
+		t.Error("Expected MinCostFlow to fail for a supply on a node not in the network")
+	}
+
+	g := New[string, int]("roads", false)
+	g.AddNode("depot", 0)
+	g.AddNode("town", 0)
+	g.AddEdge("depot", "town", 5)
+	n = FlowNetworkFromGraph(g, func(from, to string, weight float64) float64 { return 2 })
+	n.SetSupply("town", 4)
+	n.SetSupply("depot", -4)
+	res, err := n.MinCostFlow()
+	if err != nil || res.Cost != 8 || res.Flow["town"]["depot"] != 4 {
+		t.Errorf("Expected 4 units from town to depot at cost 8, got %v, %v", res, err)
+	}
+}
@@ -0,0 +1,338 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"main.go/helpers"
+)
+
+// SearchResult holds the bookkeeping of a single search call
+// Searches never write into the graph, so one Graph can be searched by many goroutines at once
+// Path is the sequence of keys from start to end, nil if no path was found
+// Cost is the sum of the edge weights along Path
+// Visited lists the nodes in the order the search visited them
+// Parents maps every reached node (except start) to the node it was reached from
+type SearchResult[K comparable] struct {
+	Path    []K
+	Cost    float64
+	Visited []K
+	Parents map[K]K
+}
+
+// ErrSearchInterrupted is wrapped by every error returned from a context-aware search that stopped early
+// The error also wraps the reason, either ctx.Err() or ErrExpansionLimit
+var ErrSearchInterrupted = errors.New("search interrupted")
+
+// ErrExpansionLimit is the reason a search stopped when it hit SearchLimits.MaxExpansions
+var ErrExpansionLimit = errors.New("expansion limit reached")
+
+// SearchLimits bounds the work done by the context-aware searches
+// MaxExpansions caps the number of nodes expanded, 0 means no cap
+// When a search is interrupted its SearchResult holds a partial answer: Path leads from start to one node
+// reached so far and Cost is the cost of that path
+// A* picks the expanded node with the lowest heuristic, its best estimate of the node closest to the goal
+// BFS, DFS and Dijkstra know nothing about the distance to the goal, so they make no attempt to pick a
+// closest node: BFS and DFS return the last node discovered and Dijkstra the last node settled, which is
+// the one farthest from start; use A* when the partial path should head toward the goal
+type SearchLimits struct {
+	MaxExpansions int
+}
+
+// Returns an error if the search must stop before doing another expansion
+func (l SearchLimits) check(ctx context.Context, expansions int) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrSearchInterrupted, err)
+	}
+	if l.MaxExpansions > 0 && expansions >= l.MaxExpansions {
+		return fmt.Errorf("%w: %w", ErrSearchInterrupted, ErrExpansionLimit)
+	}
+	return nil
+}
+
+func newSearchResult[K comparable]() *SearchResult[K] {
+	return &SearchResult[K]{
+		Visited: make([]K, 0),
+		Parents: make(map[K]K),
+	}
+}
+
+// Searchable is anything the searches can walk without a materialized Graph
+// Neighbors returns the keys reachable in one step from k together with the edge weights
+// Graph implements it, and so can infinite grids or game-state spaces built on the fly
+// A SuccessorFunc can be passed wherever a Searchable is expected
+type Searchable[K comparable] interface {
+	Neighbors(k K) []Successor[K]
+}
+
+// NodeLookup can optionally be implemented by a Searchable so searches reject unknown start or end keys
+// Without it every key is assumed to exist
+type NodeLookup[K comparable] interface {
+	ContainsNode(k K) bool
+}
+
+// NeighborIterator can optionally be implemented by a Searchable to yield neighbors without building a slice
+// The searches use it in place of Neighbors when it is there, Graph implements it over its edge map
+type NeighborIterator[K comparable] interface {
+	NeighborSeq(k K) iter.Seq2[K, float64]
+}
+
+// Returns the function the searches list neighbors with, NeighborSeq if space has it and Neighbors otherwise
+func neighborsOf[K comparable](space Searchable[K]) func(k K) iter.Seq2[K, float64] {
+	if it, ok := space.(NeighborIterator[K]); ok {
+		return it.NeighborSeq
+	}
+	return func(k K) iter.Seq2[K, float64] {
+		return func(yield func(K, float64) bool) {
+			for _, next := range space.Neighbors(k) {
+				if !yield(next.Key, next.Cost) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbors lets a SuccessorFunc be used as a Searchable
+func (f SuccessorFunc[K]) Neighbors(k K) []Successor[K] {
+	return f(k)
+}
+
+// Returns false if space can look up nodes and start or end is missing
+func containsEndpoints[K comparable](space Searchable[K], start, end K) bool {
+	if lookup, ok := space.(NodeLookup[K]); ok {
+		return lookup.ContainsNode(start) && lookup.ContainsNode(end)
+	}
+	return true
+}
+
+// Returns a path from start to end in space using BFS
+// Behaves like Graph.BFSContext, which calls it with the graph as space
+// Examples
+// res, err := SearchBFS(ctx, grid, start, end, SearchLimits{MaxExpansions: 10000})
+func SearchBFS[K comparable](ctx context.Context, space Searchable[K], start, end K, limits SearchLimits) (*SearchResult[K], error) {
+	res := newSearchResult[K]()
+	if start == end {
+		res.Path = []K{start}
+		res.Visited = append(res.Visited, start)
+		return res, nil
+	} else if !containsEndpoints(space, start, end) {
+		return nil, errors.New("start or end node not in graph")
+	}
+	neighbors := neighborsOf(space)
+
+	visited := make(map[K]bool)
+	visited[start] = true
+	costs := map[K]float64{start: 0}
+	res.Visited = append(res.Visited, start)
+	queue := []K{start}
+
+	expansions := 0
+	for len(queue) > 0 {
+		if err := limits.check(ctx, expansions); err != nil {
+			last := res.Visited[len(res.Visited)-1]
+			finishPath(res, start, last, costs[last])
+			return res, err
+		}
+		expansions++
+		node := queue[0]
+		queue = queue[1:]
+
+		for neighbor, weight := range neighbors(node) {
+			if visited[neighbor] {
+				continue
+			}
+			visited[neighbor] = true
+			res.Visited = append(res.Visited, neighbor)
+			res.Parents[neighbor] = node
+			costs[neighbor] = costs[node] + weight
+			if neighbor == end {
+				finishPath(res, start, end, costs[end])
+				return res, nil
+			}
+			queue = append(queue, neighbor)
+		}
+	}
+	return res, errors.New("no path found")
+}
+
+// Returns a path from start to end in space using DFS
+// Behaves like Graph.DFSContext, which calls it with the graph as space
+func SearchDFS[K comparable](ctx context.Context, space Searchable[K], start, end K, limits SearchLimits) (*SearchResult[K], error) {
+	res := newSearchResult[K]()
+	if start == end {
+		res.Path = []K{start}
+		res.Visited = append(res.Visited, start)
+		return res, nil
+	} else if !containsEndpoints(space, start, end) {
+		return nil, errors.New("start or end node not in graph")
+	}
+	neighbors := neighborsOf(space)
+
+	visited := make(map[K]bool)
+	visited[start] = true
+	costs := map[K]float64{start: 0}
+	res.Visited = append(res.Visited, start)
+	stack := []K{start}
+
+	expansions := 0
+	for len(stack) > 0 {
+		if err := limits.check(ctx, expansions); err != nil {
+			last := res.Visited[len(res.Visited)-1]
+			finishPath(res, start, last, costs[last])
+			return res, err
+		}
+		expansions++
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for neighbor, weight := range neighbors(node) {
+			if visited[neighbor] {
+				continue
+			}
+			visited[neighbor] = true
+			res.Visited = append(res.Visited, neighbor)
+			res.Parents[neighbor] = node
+			costs[neighbor] = costs[node] + weight
+			if neighbor == end {
+				finishPath(res, start, end, costs[end])
+				return res, nil
+			}
+			stack = append(stack, neighbor)
+		}
+	}
+	return res, errors.New("no path found")
+}
+
+// Returns a lowest cost path from start to end in space using Dijkstra's algorithm
+// Behaves like Graph.DijkstraContext, which calls it with the graph as space
+func SearchDijkstra[K comparable](ctx context.Context, space Searchable[K], start, end K, limits SearchLimits) (*SearchResult[K], error) {
+	res := newSearchResult[K]()
+	if start == end {
+		res.Path = []K{start}
+		res.Visited = append(res.Visited, start)
+		return res, nil
+	} else if !containsEndpoints(space, start, end) {
+		return nil, errors.New("start or end node not in graph")
+	}
+	neighbors := neighborsOf(space)
+	visited := make(map[K]bool)
+	cost := map[K]float64{start: 0}
+	pq := make(helpers.PriorityQueue[K], 0)
+	pq.PushItem(start, 0)
+
+	expansions := 0
+	last := start
+	for pq.Len() > 0 {
+		node := pq.PopItem()
+		if visited[node] {
+			continue
+		}
+		if err := limits.check(ctx, expansions); err != nil {
+			finishPath(res, start, last, cost[last])
+			return res, err
+		}
+		expansions++
+		visited[node] = true
+		last = node
+		res.Visited = append(res.Visited, node)
+		if node == end {
+			finishPath(res, start, end, cost[end])
+			return res, nil
+		}
+		for neighbor, weight := range neighbors(node) {
+			if visited[neighbor] {
+				continue
+			}
+			newCost := cost[node] + weight
+			if old, seen := cost[neighbor]; !seen || newCost < old {
+				cost[neighbor] = newCost
+				res.Parents[neighbor] = node
+				pq.PushItem(neighbor, newCost)
+			}
+		}
+	}
+	return res, errors.New("no path found")
+}
+
+// Returns a lowest cost path from start to end in space using A*
+// Behaves like Graph.AStarContext, which calls it with the graph as space
+// Examples
+// res, err := SearchAStar(ctx, grid, start, end, helpers.EuclideanDistance, SearchLimits{})
+func SearchAStar[K comparable](ctx context.Context, space Searchable[K], start, end K, heuristic func(a, b K) float64, limits SearchLimits) (*SearchResult[K], error) {
+	res := newSearchResult[K]()
+	if start == end {
+		res.Path = []K{start}
+		res.Visited = append(res.Visited, start)
+		return res, nil
+	} else if !containsEndpoints(space, start, end) {
+		return nil, errors.New("start or end node not in graph")
+	}
+	neighbors := neighborsOf(space)
+	// gScore is the cost of the best known path from start, f = g + heuristic is the queue priority
+	gScore := map[K]float64{start: 0}
+	closed := make(map[K]bool)
+	expanded := make(map[K]bool)
+	pq := make(helpers.PriorityQueue[K], 0)
+	// Ties on f go to the node with the higher g, which is closer to the goal
+	pq.PushItemTiebreak(start, heuristic(start, end), 0)
+
+	expansions := 0
+	best, bestH := start, heuristic(start, end)
+	for pq.Len() > 0 {
+		node := pq.PopItem()
+		if closed[node] {
+			continue
+		}
+		if err := limits.check(ctx, expansions); err != nil {
+			finishPath(res, start, best, gScore[best])
+			return res, err
+		}
+		expansions++
+		if h := heuristic(node, end); h < bestH {
+			best, bestH = node, h
+		}
+		closed[node] = true
+		if !expanded[node] {
+			expanded[node] = true
+			res.Visited = append(res.Visited, node)
+		}
+		if node == end {
+			finishPath(res, start, end, gScore[end])
+			return res, nil
+		}
+		for neighbor, weight := range neighbors(node) {
+			newG := gScore[node] + weight
+			if old, seen := gScore[neighbor]; seen && newG >= old {
+				continue
+			}
+			gScore[neighbor] = newG
+			res.Parents[neighbor] = node
+			// Reopen closed nodes so an admissible but inconsistent heuristic still gives the optimal path
+			closed[neighbor] = false
+			pq.PushItemTiebreak(neighbor, newG+heuristic(neighbor, end), -newG)
+		}
+	}
+	return res, errors.New("no path found")
+}
+
+// Fills in Path and Cost of res by walking Parents back from start to end
+// end is the goal, or the best node reached when the search was interrupted
+func finishPath[K comparable](res *SearchResult[K], start, end K, cost float64) {
+	res.Path = constructPath(res.Parents, start, end)
+	res.Cost = cost
+}
+
+// Walks the parent map back from end to start and returns the path in order
+func constructPath[K comparable](parents map[K]K, start, end K) []K {
+	path := []K{end}
+	for k := end; k != start; {
+		k = parents[k]
+		path = append(path, k)
+	}
+	slices.Reverse(path)
+	return path
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"main.go/helpers"
+)
+
+// An unbounded grid with a wall along X = 5 that has a gap at Y = 20
+type infiniteGrid struct{}
+
+func (infiniteGrid) Neighbors(k helpers.Coordinate) []Successor[helpers.Coordinate] {
+	neighbors := make([]Successor[helpers.Coordinate], 0, 4)
+	for _, dir := range helpers.GetGridDirections(false) {
+		next := helpers.Coordinate{X: k.X + float64(dir[0]), Y: k.Y + float64(dir[1])}
+		if next.X == 5 && next.Y != 20 {
+			continue
+		}
+		neighbors = append(neighbors, Successor[helpers.Coordinate]{Key: next, Cost: 1})
+	}
+	return neighbors
+}
+
+func TestSearchableInfiniteGrid(t *testing.T) {
+	ctx := context.Background()
+	start := helpers.Coordinate{X: 0, Y: 0}
+	end := helpers.Coordinate{X: 10, Y: 0}
+	// Around the wall: 5 right, 20 up, 5 right, 20 down
+	const want = 50
+
+	res, err := SearchAStar(ctx, infiniteGrid{}, start, end, helpers.EuclideanDistance, SearchLimits{})
+	if err != nil {
+		t.Fatalf("SearchAStar failed: %v", err)
+	}
+	if res.Cost != want || len(res.Path) != want+1 {
+		t.Errorf("SearchAStar found path of cost %f, expected %d", res.Cost, want)
+	}
+
+	res, err = SearchBFS(ctx, infiniteGrid{}, start, end, SearchLimits{})
+	if err != nil {
+		t.Fatalf("SearchBFS failed: %v", err)
+	}
+	if res.Cost != want {
+		t.Errorf("SearchBFS found path of cost %f, expected %d", res.Cost, want)
+	}
+
+	// DFS on an unbounded space only ends through its limits
+	if _, err := SearchDFS(ctx, infiniteGrid{}, start, end, SearchLimits{MaxExpansions: 1000}); err != nil && !errors.Is(err, ErrExpansionLimit) {
+		t.Errorf("Expected SearchDFS to succeed or hit its limit, got %v", err)
+	}
+}
+
+func TestSearchableSuccessorFunc(t *testing.T) {
+	// Collatz-style steps: n -> n/2 costs 1, n -> 3n+1 costs 3
+	steps := SuccessorFunc[int](func(n int) []Successor[int] {
+		next := []Successor[int]{{Key: 3*n + 1, Cost: 3}}
+		if n%2 == 0 {
+			next = append(next, Successor[int]{Key: n / 2, Cost: 1})
+		}
+		return next
+	})
+	res, err := SearchDijkstra(context.Background(), steps, 6, 1, SearchLimits{MaxExpansions: 10000})
+	if err != nil {
+		t.Fatalf("SearchDijkstra failed: %v", err)
+	}
+	// 6 -> 3 -> 10 -> 5 -> 16 -> 8 -> 4 -> 2 -> 1
+	if res.Cost != 12 || len(res.Path) != 9 {
+		t.Errorf("SearchDijkstra found %v at cost %f, expected 8 steps at cost 12", res.Path, res.Cost)
+	}
+
+	g := New[string, int]("lookup", true)
+	g.AddNode("A", 1)
+	if _, err := SearchBFS(context.Background(), g, "A", "missing", SearchLimits{}); err == nil {
+		t.Error("Expected SearchBFS to reject an end node missing from the graph")
+	}
+}
+
+// Returns the weighted cycle 0..n-1 with a shortcut from 0 to n/2 costing 5
+func newShortcutCycle(n int) *Graph[int, int] {
+	g := New[int, int]("cycle", false)
+	for i := 0; i < n; i++ {
+		g.AddNode(i, i)
+	}
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, (i+1)%n, 1)
+	}
+	g.AddEdge(0, n/2, 5)
+	return g
+}
+
+func TestSearchableNeighborSeq(t *testing.T) {
+	g := newShortcutCycle(200)
+	for _, k := range []int{0, 1, 100} {
+		yielded := make(map[int]float64)
+		for neighbor, weight := range g.NeighborSeq(k) {
+			yielded[neighbor] = weight
+		}
+		if !maps.Equal(yielded, g.Edges[k]) {
+			t.Errorf("NeighborSeq(%d) gave %v, expected %v", k, yielded, g.Edges[k])
+		}
+	}
+
+	// The graph is searched through NeighborSeq, the same graph behind a SuccessorFunc through Neighbors
+	ctx := context.Background()
+	sliced := SuccessorFunc[int](g.Neighbors)
+	for _, end := range []int{120, 199, 60} {
+		res, err := SearchDijkstra(ctx, g, 0, end, SearchLimits{})
+		other, otherErr := SearchDijkstra(ctx, sliced, 0, end, SearchLimits{})
+		if err != nil || otherErr != nil {
+			t.Fatalf("SearchDijkstra failed: %v, %v", err, otherErr)
+		}
+		if res.Cost != other.Cost || !slices.Equal(res.Path, other.Path) {
+			t.Errorf("Searching to %d gave %v at cost %f and %v at cost %f", end, res.Path, res.Cost, other.Path, other.Cost)
+		}
+	}
+}
+
+func BenchmarkSearchGraph(b *testing.B) {
+	g := newShortcutCycle(2000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SearchDijkstra(context.Background(), g, 0, 1500, SearchLimits{})
+	}
+}
+
+// Same search with the graph hidden behind a SuccessorFunc, which builds a neighbor slice per expansion
+func BenchmarkSearchGraphNeighbors(b *testing.B) {
+	g := newShortcutCycle(2000)
+	sliced := SuccessorFunc[int](g.Neighbors)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SearchDijkstra(context.Background(), sliced, 0, 1500, SearchLimits{})
+	}
+}
@@ -0,0 +1,315 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"main.go/helpers"
+)
+
+// ShortestPaths holds the single-source shortest path distances from Source
+// Dist has an entry for every node in the graph, unreachable nodes are math.Inf(1)
+// Parents maps every reached node (except Source) to its predecessor on the shortest path
+type ShortestPaths[K comparable] struct {
+	Source  K
+	Dist    map[K]float64
+	Parents map[K]K
+}
+
+// Returns the shortest path from Source to k
+// Returns false if k is not reachable
+// Examples
+// sp, _ := g.BellmanFord("A")
+// path, ok := sp.PathTo("C")
+// fmt.Println(path, ok) // Output: [A B C] true
+func (sp *ShortestPaths[K]) PathTo(k K) ([]K, bool) {
+	if d, exists := sp.Dist[k]; !exists || math.IsInf(d, 1) {
+		return nil, false
+	}
+	return constructPath(sp.Parents, sp.Source, k), true
+}
+
+// NegativeCycleError is returned when a negative weight cycle is reachable from the source
+// Cycle lists the node keys in edge order, the last node has an edge back to the first
+type NegativeCycleError[K comparable] struct {
+	Cycle []K
+}
+
+func (e *NegativeCycleError[K]) Error() string {
+	return fmt.Sprintf("negative cycle found: %v", e.Cycle)
+}
+
+func (g *Graph[K, V]) newShortestPaths(source K) *ShortestPaths[K] {
+	sp := &ShortestPaths[K]{
+		Source:  source,
+		Dist:    make(map[K]float64, len(g.Nodes)),
+		Parents: make(map[K]K),
+	}
+	for k := range g.Nodes {
+		sp.Dist[k] = math.Inf(1)
+	}
+	sp.Dist[source] = 0
+	return sp
+}
+
+// Returns the shortest distances from source to every node using the Bellman-Ford algorithm
+// Unlike Dijkstra, negative edge weights are allowed
+// In an undirected graph a negative edge is itself a negative cycle
+// If a negative cycle is reachable from source, returns a *NegativeCycleError
+// Examples
+// g := New[string, int]("MyGraph", true)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 4)
+// g.AddEdge("B", "C", -2)
+// g.AddEdge("A", "C", 3)
+// sp, err := g.BellmanFord("A")
+// fmt.Println(sp.Dist["C"]) // Output: 2
+func (g *Graph[K, V]) BellmanFord(source K) (*ShortestPaths[K], error) {
+	if !g.ContainsNode(source) {
+		return nil, errors.New("source node not in graph")
+	}
+	sp := g.newShortestPaths(source)
+	if err := g.relaxEdges(sp.Dist, sp.Parents); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+// Runs the Bellman-Ford passes over dist and parents until nothing relaxes
+// Nodes at math.Inf(1) are treated as not yet reached
+// Returns a *NegativeCycleError if the n-th pass still relaxes an edge
+func (g *Graph[K, V]) relaxEdges(dist map[K]float64, parents map[K]K) error {
+	for i := 0; i < len(g.Nodes); i++ {
+		var changed K
+		relaxed := false
+		for u, edges := range g.Edges {
+			if math.IsInf(dist[u], 1) {
+				continue
+			}
+			for v, weight := range edges {
+				if newDist := dist[u] + weight; newDist < dist[v] {
+					dist[v] = newDist
+					parents[v] = u
+					changed = v
+					relaxed = true
+				}
+			}
+		}
+		if !relaxed {
+			return nil
+		}
+		// A relaxation on the n-th pass means a negative cycle
+		if i == len(g.Nodes)-1 {
+			return &NegativeCycleError[K]{Cycle: findParentCycle(parents, changed)}
+		}
+	}
+	return nil
+}
+
+// Returns the shortest distances from source to every node using the
+// Shortest Path Faster Algorithm, a queue-based Bellman-Ford
+// Only nodes whose distance changed are re-examined, which is usually much faster on sparse graphs
+// If a negative cycle is reachable from source, returns a *NegativeCycleError
+// Examples
+// sp, err := g.SPFA("A")
+//
+//	var cycleErr *NegativeCycleError[string]
+//	if errors.As(err, &cycleErr) {
+//	    fmt.Println("Cycle:", cycleErr.Cycle)
+//	}
+func (g *Graph[K, V]) SPFA(source K) (*ShortestPaths[K], error) {
+	if !g.ContainsNode(source) {
+		return nil, errors.New("source node not in graph")
+	}
+	sp := g.newShortestPaths(source)
+	// hops is the number of edges on the current best path to a node
+	hops := map[K]int{source: 0}
+	inQueue := map[K]bool{source: true}
+	queue := []K{source}
+
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		inQueue[u] = false
+
+		for v, weight := range g.Edges[u] {
+			newDist := sp.Dist[u] + weight
+			if newDist >= sp.Dist[v] {
+				continue
+			}
+			sp.Dist[v] = newDist
+			sp.Parents[v] = u
+			hops[v] = hops[u] + 1
+			// A shortest path can't use more than n-1 edges
+			if hops[v] >= len(g.Nodes) {
+				if cycle := findParentCycle(sp.Parents, v); cycle != nil {
+					return nil, &NegativeCycleError[K]{Cycle: cycle}
+				}
+				// Parent pointers moved on since the hop count was taken, let Bellman-Ford locate the cycle
+				return g.BellmanFord(source)
+			}
+			if !inQueue[v] {
+				inQueue[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	return sp, nil
+}
+
+// Follows parent pointers from k and returns the first cycle found in edge order
+// Returns nil if the walk ends without repeating a node
+func findParentCycle[K comparable](parents map[K]K, k K) []K {
+	position := make(map[K]int)
+	walk := make([]K, 0)
+	for {
+		if i, seen := position[k]; seen {
+			cycle := slices.Clone(walk[i:])
+			slices.Reverse(cycle)
+			return cycle
+		}
+		position[k] = len(walk)
+		walk = append(walk, k)
+		parent, exists := parents[k]
+		if !exists {
+			return nil
+		}
+		k = parent
+	}
+}
+
+// Runs Dijkstra over adjacency from source to every reachable node, pricing each edge with weight
+// If stop is not nil the search ends as soon as stop returns true for a settled node
+// Returns the distances and parents of the reached nodes and the order they were settled in
+// Only the nodes in the settled order have final distances and parents
+func dijkstraTree[K comparable](adjacency map[K]map[K]float64, source K, weight func(u, v K, w float64) float64, stop func(k K) bool) (map[K]float64, map[K]K, []K) {
+	dist := map[K]float64{source: 0}
+	parents := make(map[K]K)
+	order := make([]K, 0)
+	settled := make(map[K]bool)
+	pq := make(helpers.PriorityQueue[K], 0)
+	pq.PushItem(source, 0)
+
+	for pq.Len() > 0 {
+		u := pq.PopItem()
+		if settled[u] {
+			continue
+		}
+		settled[u] = true
+		order = append(order, u)
+		if stop != nil && stop(u) {
+			break
+		}
+		for v, w := range adjacency[u] {
+			if settled[v] {
+				continue
+			}
+			newDist := dist[u] + weight(u, v, w)
+			if old, seen := dist[v]; !seen || newDist < old {
+				dist[v] = newDist
+				parents[v] = u
+				pq.PushItem(v, newDist)
+			}
+		}
+	}
+	return dist, parents, order
+}
+
+// Returns the graph's own edge weight
+func edgeWeight[K comparable](u, v K, w float64) float64 {
+	return w
+}
+
+// Copies the settled part of a dijkstraTree run into a ShortestPaths
+func (g *Graph[K, V]) settledPaths(source K, dist map[K]float64, parents map[K]K, order []K) *ShortestPaths[K] {
+	sp := g.newShortestPaths(source)
+	for _, k := range order {
+		sp.Dist[k] = dist[k]
+		if k != source {
+			sp.Parents[k] = parents[k]
+		}
+	}
+	return sp
+}
+
+// Returns the shortest path tree from source to every reachable node using Dijkstra's algorithm
+// Unlike Dijkstra it does not stop at a single end node, so one call answers every target
+// Edge weights must be non-negative, use BellmanFord otherwise
+// Examples
+// g := New[string, int]("MyGraph", true)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 2)
+// g.AddEdge("B", "C", 2)
+// sp, err := g.DijkstraAll("A")
+// fmt.Println(sp.Dist) // Output: map[A:0 B:2 C:4]
+func (g *Graph[K, V]) DijkstraAll(source K) (*ShortestPaths[K], error) {
+	if !g.ContainsNode(source) {
+		return nil, errors.New("source node not in graph")
+	}
+	dist, parents, order := dijkstraTree(g.Edges, source, edgeWeight, nil)
+	return g.settledPaths(source, dist, parents, order), nil
+}
+
+// Same as DijkstraAll but stops once every target has been settled
+// Nodes that were not settled before the search stopped are left at math.Inf(1)
+// Targets that are not in the graph are ignored
+// Examples
+// sp, err := g.DijkstraTargets("A", []string{"B", "C"})
+// fmt.Println(sp.Dist["B"], sp.Dist["C"]) // Output: 2 4
+func (g *Graph[K, V]) DijkstraTargets(source K, targets []K) (*ShortestPaths[K], error) {
+	if !g.ContainsNode(source) {
+		return nil, errors.New("source node not in graph")
+	}
+	remaining := make(map[K]bool, len(targets))
+	for _, k := range targets {
+		if g.ContainsNode(k) {
+			remaining[k] = true
+		}
+	}
+	if len(remaining) == 0 {
+		return g.newShortestPaths(source), nil
+	}
+	stop := func(k K) bool {
+		delete(remaining, k)
+		return len(remaining) == 0
+	}
+	dist, parents, order := dijkstraTree(g.Edges, source, edgeWeight, stop)
+	return g.settledPaths(source, dist, parents, order), nil
+}
+
+// Returns the path to whichever of targets is closest to source
+// The search stops as soon as the first target is settled
+// Returns an error if none of the targets is reachable
+// Examples
+// res, err := g.NearestTarget("A", []string{"B", "C"})
+// fmt.Println(res.Path, res.Cost) // Output: [A B] 2
+func (g *Graph[K, V]) NearestTarget(source K, targets []K) (*SearchResult[K], error) {
+	if !g.ContainsNode(source) {
+		return nil, errors.New("source node not in graph")
+	}
+	isTarget := make(map[K]bool, len(targets))
+	for _, k := range targets {
+		isTarget[k] = true
+	}
+	dist, parents, order := dijkstraTree(g.Edges, source, edgeWeight, func(k K) bool { return isTarget[k] })
+	res := newSearchResult[K]()
+	res.Visited = order
+	for _, k := range order {
+		if k != source {
+			res.Parents[k] = parents[k]
+		}
+	}
+	nearest := order[len(order)-1]
+	if !isTarget[nearest] {
+		return res, errors.New("no path found")
+	}
+	res.Path = constructPath(res.Parents, source, nearest)
+	res.Cost = dist[nearest]
+	return res, nil
+}
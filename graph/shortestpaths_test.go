@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func newNegativeGraph() *Graph[string, int] {
+	g := New[string, int]("negative", true)
+	for _, k := range []string{"A", "B", "C", "D", "E"} {
+		g.AddNode(k, 0)
+	}
+	g.AddEdge("A", "B", 4)
+	g.AddEdge("A", "C", 3)
+	g.AddEdge("B", "C", -2)
+	g.AddEdge("C", "D", 2)
+	g.AddEdge("B", "D", 5)
+	return g
+}
+
+func TestBellmanFordNegativeWeights(t *testing.T) {
+	g := newNegativeGraph()
+	want := map[string]float64{"A": 0, "B": 4, "C": 2, "D": 4, "E": math.Inf(1)}
+	for name, run := range map[string]func(string) (*ShortestPaths[string], error){
+		"BellmanFord": g.BellmanFord,
+		"SPFA":        g.SPFA,
+	} {
+		sp, err := run("A")
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if !reflect.DeepEqual(sp.Dist, want) {
+			t.Errorf("%s distances %v, expected %v", name, sp.Dist, want)
+		}
+		path, ok := sp.PathTo("D")
+		if !ok || !reflect.DeepEqual(path, []string{"A", "B", "C", "D"}) {
+			t.Errorf("%s path %v, expected [A B C D]", name, path)
+		}
+		if _, ok := sp.PathTo("E"); ok {
+			t.Errorf("%s found a path to unreachable node E", name)
+		}
+	}
+}
+
+func TestNegativeCycle(t *testing.T) {
+	g := newNegativeGraph()
+	g.AddEdge("D", "B", -4)
+	for name, run := range map[string]func(string) (*ShortestPaths[string], error){
+		"BellmanFord": g.BellmanFord,
+		"SPFA":        g.SPFA,
+	} {
+		_, err := run("A")
+		var cycleErr *NegativeCycleError[string]
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("%s: expected NegativeCycleError, got %v", name, err)
+		}
+		total := 0.0
+		for i, k := range cycleErr.Cycle {
+			next := cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)]
+			if !g.ContainsEdge(k, next) {
+				t.Fatalf("%s: cycle %v uses missing edge %s->%s", name, cycleErr.Cycle, k, next)
+			}
+			total += g.GetEdgeWeight(k, next)
+		}
+		if total >= 0 {
+			t.Errorf("%s: cycle %v has non-negative weight %f", name, cycleErr.Cycle, total)
+		}
+	}
+}
+
+func TestBellmanFordMatchesDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	g := randomGraph{n: 40, p: 0.13, directed: true, weight: func(rng *rand.Rand) float64 {
+		return float64(rng.Intn(20))
+	}}.build(rng)
+	bf, err := g.BellmanFord(0)
+	if err != nil {
+		t.Fatalf("BellmanFord failed: %v", err)
+	}
+	spfa, err := g.SPFA(0)
+	if err != nil {
+		t.Fatalf("SPFA failed: %v", err)
+	}
+	for k := range g.Nodes {
+		res, err := g.DijkstraResult(0, k)
+		want := math.Inf(1)
+		if err == nil {
+			want = res.Cost
+		}
+		if bf.Dist[k] != want || spfa.Dist[k] != want {
+			t.Errorf("Node %d: BellmanFord %f, SPFA %f, Dijkstra %f", k, bf.Dist[k], spfa.Dist[k], want)
+		}
+	}
+}
+
+func TestDijkstraAllAndTargets(t *testing.T) {
+	g := New[string, int]("depots", false)
+	for _, k := range []string{"A", "B", "C", "D", "E", "F"} {
+		g.AddNode(k, 0)
+	}
+	g.AddEdge("A", "B", 2)
+	g.AddEdge("B", "C", 2)
+	g.AddEdge("A", "D", 5)
+	g.AddEdge("C", "D", 1)
+	g.AddEdge("D", "E", 10)
+
+	sp, err := g.DijkstraAll("A")
+	if err != nil {
+		t.Fatalf("DijkstraAll failed: %v", err)
+	}
+	want := map[string]float64{"A": 0, "B": 2, "C": 4, "D": 5, "E": 15, "F": math.Inf(1)}
+	if !reflect.DeepEqual(sp.Dist, want) {
+		t.Errorf("DijkstraAll distances %v, expected %v", sp.Dist, want)
+	}
+	if path, ok := sp.PathTo("E"); !ok || g.pathCost(path) != 15 {
+		t.Errorf("DijkstraAll path to E %v does not cost 15", path)
+	}
+
+	sp, err = g.DijkstraTargets("A", []string{"B", "C"})
+	if err != nil {
+		t.Fatalf("DijkstraTargets failed: %v", err)
+	}
+	if sp.Dist["B"] != 2 || sp.Dist["C"] != 4 {
+		t.Errorf("DijkstraTargets distances %v", sp.Dist)
+	}
+	if !math.IsInf(sp.Dist["E"], 1) {
+		t.Errorf("DijkstraTargets settled E at %f, expected it to stop before", sp.Dist["E"])
+	}
+
+	res, err := g.NearestTarget("A", []string{"E", "C", "F"})
+	if err != nil {
+		t.Fatalf("NearestTarget failed: %v", err)
+	}
+	if !reflect.DeepEqual(res.Path, []string{"A", "B", "C"}) || res.Cost != 4 {
+		t.Errorf("NearestTarget found %v at cost %f, expected [A B C] at cost 4", res.Path, res.Cost)
+	}
+	if _, err := g.NearestTarget("A", []string{"F"}); err == nil {
+		t.Error("Expected NearestTarget to fail for an unreachable target")
+	}
+}
@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"main.go/helpers"
+)
+
+var errDirectedSpanningTree = errors.New("minimum spanning trees need an undirected graph")
+
+// Returns every undirected edge once, skipping self loops
+func (g *Graph[K, V]) undirectedEdges() []Edge[K] {
+	seen := make(map[[2]K]bool)
+	edges := make([]Edge[K], 0, g.LengthEdges()/2)
+	for u, neighbors := range g.Edges {
+		for v, weight := range neighbors {
+			if u == v || seen[[2]K{v, u}] {
+				continue
+			}
+			seen[[2]K{u, v}] = true
+			edges = append(edges, Edge[K]{From: u, To: v, Weight: weight})
+		}
+	}
+	return edges
+}
+
+// Returns a new undirected graph with every node of g and only the given edges, plus their total weight
+func (g *Graph[K, V]) spanningForest(edges []Edge[K]) (*Graph[K, V], float64) {
+	forest := New[K, V](g.Name, false)
+	for k, node := range g.Nodes {
+		forest.AddNode(k, node.Value)
+	}
+	total := 0.0
+	for _, e := range edges {
+		forest.AddEdge(e.From, e.To, e.Weight)
+		total += e.Weight
+	}
+	return forest, total
+}
+
+// Returns the minimum spanning forest of an undirected graph using Kruskal's algorithm
+// Edges are taken cheapest first and kept unless they would close a cycle, tracked with helpers.UnionFind
+// The forest has one tree per connected component, so a connected graph gives its minimum spanning tree
+// Returns the forest as a new graph holding every node, plus its total weight
+// Returns an error for a directed graph
+// Examples
+// g := New[string, int]("MyGraph", false)
+// g.AddNode("A", 1)
+// g.AddNode("B", 2)
+// g.AddNode("C", 3)
+// g.AddEdge("A", "B", 1)
+// g.AddEdge("B", "C", 2)
+// g.AddEdge("A", "C", 3)
+// mst, total, err := g.Kruskal()
+// fmt.Println(mst.LengthEdges(), total) // Output: 4 3
+func (g *Graph[K, V]) Kruskal() (*Graph[K, V], float64, error) {
+	if g.IsDirected {
+		return nil, 0, errDirectedSpanningTree
+	}
+	edges := g.undirectedEdges()
+	slices.SortStableFunc(edges, func(a, b Edge[K]) int { return cmp.Compare(a.Weight, b.Weight) })
+
+	uf := helpers.NewUnionFind[K]()
+	chosen := make([]Edge[K], 0, len(g.Nodes))
+	for _, e := range edges {
+		if uf.Union(e.From, e.To) {
+			chosen = append(chosen, e)
+		}
+	}
+	forest, total := g.spanningForest(chosen)
+	return forest, total, nil
+}
+
+// Returns the minimum spanning forest of an undirected graph using Prim's algorithm
+// Each tree grows from an unvisited node by repeatedly adding the cheapest edge leaving it
+// Suits dense graphs better than Kruskal since edges are never sorted up front
+// Returns an error for a directed graph
+// Examples
+// mst, total, err := g.Prim()
+// fmt.Println(total) // Output: 3
+func (g *Graph[K, V]) Prim() (*Graph[K, V], float64, error) {
+	if g.IsDirected {
+		return nil, 0, errDirectedSpanningTree
+	}
+	inTree := make(map[K]bool, len(g.Nodes))
+	chosen := make([]Edge[K], 0, len(g.Nodes))
+	for root := range g.Nodes {
+		if inTree[root] {
+			continue
+		}
+		inTree[root] = true
+		pq := make(helpers.PriorityQueue[Edge[K]], 0)
+		for v, weight := range g.Edges[root] {
+			pq.PushItem(Edge[K]{From: root, To: v, Weight: weight}, weight)
+		}
+		for pq.Len() > 0 {
+			e := pq.PopItem()
+			if inTree[e.To] {
+				continue
+			}
+			inTree[e.To] = true
+			chosen = append(chosen, e)
+			for v, weight := range g.Edges[e.To] {
+				if !inTree[v] {
+					pq.PushItem(Edge[K]{From: e.To, To: v, Weight: weight}, weight)
+				}
+			}
+		}
+	}
+	forest, total := g.spanningForest(chosen)
+	return forest, total, nil
+}
+
+// Returns the minimum spanning forest of an undirected graph using Borůvka's algorithm
+// Every round each component picks its cheapest outgoing edge and all of them are added at once,
+// so the number of components at least halves each round
+// Returns an error for a directed graph
+// Examples
+// mst, total, err := g.Boruvka()
+// fmt.Println(total) // Output: 3
+func (g *Graph[K, V]) Boruvka() (*Graph[K, V], float64, error) {
+	if g.IsDirected {
+		return nil, 0, errDirectedSpanningTree
+	}
+	edges := g.undirectedEdges()
+	uf := helpers.NewUnionFind[K]()
+	for k := range g.Nodes {
+		uf.Add(k)
+	}
+	// Ties are broken by edge position so every component agrees on the cheapest edge
+	cheaper := func(i, j int) bool {
+		if edges[i].Weight != edges[j].Weight {
+			return edges[i].Weight < edges[j].Weight
+		}
+		return i < j
+	}
+
+	chosen := make([]Edge[K], 0, len(g.Nodes))
+	for {
+		cheapest := make(map[K]int)
+		for i, e := range edges {
+			rootU, rootV := uf.Find(e.From), uf.Find(e.To)
+			if rootU == rootV {
+				continue
+			}
+			for _, root := range []K{rootU, rootV} {
+				if best, exists := cheapest[root]; !exists || cheaper(i, best) {
+					cheapest[root] = i
+				}
+			}
+		}
+		if len(cheapest) == 0 {
+			break
+		}
+		for _, i := range cheapest {
+			if uf.Union(edges[i].From, edges[i].To) {
+				chosen = append(chosen, edges[i])
+			}
+		}
+	}
+	forest, total := g.spanningForest(chosen)
+	return forest, total, nil
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMinimumSpanningForest(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	g := randomGraph{n: 40, p: 0.19, weight: func(rng *rand.Rand) float64 {
+		return float64(rng.Intn(10))
+	}}.build(rng)
+	for i := 40; i < 50; i++ {
+		g.AddNode(i, i)
+	}
+	// Nodes 40 to 49 only form a small separate tree and isolated nodes
+	g.AddEdge(40, 41, 3)
+	g.AddEdge(41, 42, 1)
+
+	kruskal, want, err := g.Kruskal()
+	if err != nil {
+		t.Fatalf("Kruskal failed: %v", err)
+	}
+	components := 0
+	seen := make(map[int]bool)
+	for k := range g.Nodes {
+		if seen[k] {
+			continue
+		}
+		components++
+		sp, _ := g.DijkstraAll(k)
+		for reached, d := range sp.Dist {
+			if !math.IsInf(d, 1) {
+				seen[reached] = true
+			}
+		}
+	}
+
+	for name, run := range map[string]func() (*Graph[int, int], float64, error){
+		"Kruskal": func() (*Graph[int, int], float64, error) { return kruskal, want, nil },
+		"Prim":    g.Prim,
+		"Boruvka": g.Boruvka,
+	} {
+		forest, total, err := run()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if total != want {
+			t.Errorf("%s total weight %f, Kruskal %f", name, total, want)
+		}
+		if forest.LengthNodes() != g.LengthNodes() {
+			t.Errorf("%s forest has %d nodes, expected %d", name, forest.LengthNodes(), g.LengthNodes())
+		}
+		if forest.LengthEdges()/2 != g.LengthNodes()-components {
+			t.Errorf("%s forest has %d edges, expected %d", name, forest.LengthEdges()/2, g.LengthNodes()-components)
+		}
+		for u, edges := range forest.Edges {
+			for v, weight := range edges {
+				if g.GetEdgeWeight(u, v) != weight {
+					t.Errorf("%s forest edge %d-%d with weight %f is not in the graph", name, u, v, weight)
+				}
+			}
+		}
+	}
+}
+
+func TestMinimumSpanningTreeDirected(t *testing.T) {
+	g := New[string, int]("directed", true)
+	g.AddNode("A", 1)
+	g.AddNode("B", 2)
+	g.AddEdge("A", "B", 1)
+	if _, _, err := g.Kruskal(); err == nil {
+		t.Error("Expected Kruskal to reject a directed graph")
+	}
+	if _, _, err := g.Prim(); err == nil {
+		t.Error("Expected Prim to reject a directed graph")
+	}
+	if _, _, err := g.Boruvka(); err == nil {
+		t.Error("Expected Boruvka to reject a directed graph")
+	}
+}
@@ -0,0 +1,384 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Tour is a closed route through the stops of a distance matrix
+// Order lists every stop index once starting from stop 0, the route returns to stop 0 after the last one
+// Cost is the total distance including that return leg
+type Tour struct {
+	Order []int
+	Cost  float64
+}
+
+// TSPSolver finds a short tour over a square distance matrix, dist[i][j] is the distance from stop i to stop j
+// HeldKarp, LocalSearchTSP and Christofides are TSPSolvers
+type TSPSolver func(ctx context.Context, dist [][]float64) (*Tour, error)
+
+// Stop counts up to this are solved by HeldKarp, its table grows as 2^n * n
+const heldKarpLimit = 18
+
+// Returns the total distance of the closed tour through order
+func tourCost(dist [][]float64, order []int) float64 {
+	cost := 0.0
+	for i, stop := range order {
+		cost += dist[stop][order[(i+1)%len(order)]]
+	}
+	return cost
+}
+
+// Returns an error unless dist is a non-empty square matrix of finite distances
+func checkDistances(dist [][]float64) error {
+	if len(dist) == 0 {
+		return errors.New("distance matrix is empty")
+	}
+	for _, row := range dist {
+		if len(row) != len(dist) {
+			return errors.New("distance matrix must be square")
+		}
+		for _, d := range row {
+			if math.IsNaN(d) || math.IsInf(d, 0) {
+				return errors.New("distances must be finite")
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the shortest path distance between every pair of stops, ready for a TSPSolver
+// Row and column i belong to stops[i], legs may pass through nodes that are not stops
+// Runs Dijkstra once per stop, so edge weights must be non-negative
+// Returns an error if a stop is missing or can't reach another stop
+// Examples
+// dist, err := g.TourDistances([]string{"depot", "shop", "school"})
+// tour, err := LocalSearchTSP(ctx, dist)
+func (g *Graph[K, V]) TourDistances(stops []K) ([][]float64, error) {
+	dist, _, err := g.tourLegs(stops)
+	return dist, err
+}
+
+// Returns the stop distances and the shortest path tree from every stop
+func (g *Graph[K, V]) tourLegs(stops []K) ([][]float64, []*ShortestPaths[K], error) {
+	dist := make([][]float64, len(stops))
+	trees := make([]*ShortestPaths[K], len(stops))
+	for i, from := range stops {
+		sp, err := g.DijkstraAll(from)
+		if err != nil {
+			return nil, nil, fmt.Errorf("stop %v not in graph", from)
+		}
+		trees[i] = sp
+		dist[i] = make([]float64, len(stops))
+		for j, to := range stops {
+			dist[i][j] = sp.Dist[to]
+			if math.IsInf(dist[i][j], 1) {
+				return nil, nil, fmt.Errorf("stop %v can't reach stop %v", from, to)
+			}
+		}
+	}
+	return dist, trees, nil
+}
+
+// Returns a closed route through the graph that starts at stops[0], visits every stop and comes back
+// solve picks the visiting order from the stop distances, each leg follows a shortest path
+// A solver stopped by ctx still gives its best route so far, along with its error
+// Examples
+// ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+// defer cancel()
+// route, err := g.PlanTour(ctx, []string{"depot", "shop", "school"}, LocalSearchTSP)
+func (g *Graph[K, V]) PlanTour(ctx context.Context, stops []K, solve TSPSolver) (WeightedPath[K], error) {
+	dist, trees, err := g.tourLegs(stops)
+	if err != nil {
+		return WeightedPath[K]{}, err
+	}
+	tour, err := solve(ctx, dist)
+	if tour == nil {
+		return WeightedPath[K]{}, err
+	}
+	route := WeightedPath[K]{Path: []K{stops[tour.Order[0]]}, Cost: tour.Cost}
+	for i, from := range tour.Order {
+		to := tour.Order[(i+1)%len(tour.Order)]
+		leg, _ := trees[from].PathTo(stops[to])
+		route.Path = append(route.Path, leg[1:]...)
+	}
+	return route, err
+}
+
+// Returns an optimal tour using the Held-Karp dynamic program, O(2^n n^2) time
+// Works for asymmetric distances too, but only up to 18 stops
+// Returns an error wrapping ErrSearchInterrupted if ctx ends first, there is no partial tour
+// Examples
+//
+//	dist := [][]float64{
+//		{0, 2, 9},
+//		{2, 0, 6},
+//		{9, 6, 0},
+//	}
+//
+// tour, err := HeldKarp(context.Background(), dist)
+// fmt.Println(tour.Order, tour.Cost) // Output: [0 1 2] 17
+func HeldKarp(ctx context.Context, dist [][]float64) (*Tour, error) {
+	if err := checkDistances(dist); err != nil {
+		return nil, err
+	}
+	n := len(dist)
+	if n > heldKarpLimit {
+		return nil, fmt.Errorf("held-karp handles at most %d stops, got %d", heldKarpLimit, n)
+	}
+	if n <= 2 {
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		return &Tour{Order: order, Cost: tourCost(dist, order)}, nil
+	}
+	// Stop 0 is the fixed start, bit j-1 of a mask stands for stop j
+	// cost[mask*m+j-1] is the cheapest walk from 0 through the stops in mask ending at j
+	m := n - 1
+	cost := make([]float64, (1<<m)*m)
+	parent := make([]int8, (1<<m)*m)
+	for i := range cost {
+		cost[i] = math.Inf(1)
+	}
+	for j := 1; j < n; j++ {
+		cost[(1<<(j-1))*m+j-1] = dist[0][j]
+		parent[(1<<(j-1))*m+j-1] = 0
+	}
+	for mask := 1; mask < 1<<m; mask++ {
+		if mask%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrSearchInterrupted, err)
+			}
+		}
+		for j := 1; j < n; j++ {
+			if mask&(1<<(j-1)) == 0 {
+				continue
+			}
+			prev := mask &^ (1 << (j - 1))
+			if prev == 0 {
+				continue
+			}
+			best, from := math.Inf(1), 0
+			for k := 1; k < n; k++ {
+				if prev&(1<<(k-1)) == 0 {
+					continue
+				}
+				if c := cost[prev*m+k-1] + dist[k][j]; c < best {
+					best, from = c, k
+				}
+			}
+			cost[mask*m+j-1] = best
+			parent[mask*m+j-1] = int8(from)
+		}
+	}
+
+	full := 1<<m - 1
+	best, last := math.Inf(1), 0
+	for j := 1; j < n; j++ {
+		if c := cost[full*m+j-1] + dist[j][0]; c < best {
+			best, last = c, j
+		}
+	}
+	order := make([]int, 0, n)
+	for mask, j := full, last; j != 0; {
+		order = append(order, j)
+		next := int(parent[mask*m+j-1])
+		mask &^= 1 << (j - 1)
+		j = next
+	}
+	order = append(order, 0)
+	slices.Reverse(order)
+	return &Tour{Order: order, Cost: best}, nil
+}
+
+// Returns a good tour for large instances: nearest neighbor from stop 0, then 2-opt and Or-opt moves
+// 2-opt reverses a stretch of the tour, Or-opt moves a run of up to three stops elsewhere;
+// both repeat until no move shortens the tour, which usually lands within a few percent of optimal
+// Works for asymmetric distances too, reversals are priced in both directions
+// If ctx ends first the best tour so far is returned with an error wrapping ErrSearchInterrupted
+func LocalSearchTSP(ctx context.Context, dist [][]float64) (*Tour, error) {
+	if err := checkDistances(dist); err != nil {
+		return nil, err
+	}
+	order := nearestNeighborTour(dist)
+	tour := &Tour{Order: order}
+	for improved := true; improved; {
+		if err := ctx.Err(); err != nil {
+			tour.Cost = tourCost(dist, tour.Order)
+			return tour, fmt.Errorf("%w: %w", ErrSearchInterrupted, err)
+		}
+		improved = twoOpt(dist, tour.Order)
+		improved = orOpt(dist, tour.Order) || improved
+	}
+	tour.Cost = tourCost(dist, tour.Order)
+	return tour, nil
+}
+
+// Returns a tour that always goes to the closest stop not yet visited, starting at stop 0
+func nearestNeighborTour(dist [][]float64) []int {
+	n := len(dist)
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	visited[0] = true
+	order = append(order, 0)
+	for len(order) < n {
+		last, next := order[len(order)-1], -1
+		for j := 0; j < n; j++ {
+			if !visited[j] && (next == -1 || dist[last][j] < dist[last][next]) {
+				next = j
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+	}
+	return order
+}
+
+// Makes one pass of improving 2-opt moves over order in place, returns true if any move was made
+// A move reverses order[i+1..j], replacing the edges leaving order[i] and order[j]
+func twoOpt(dist [][]float64, order []int) bool {
+	n := len(order)
+	improved := false
+	for i := 0; i < n-2; i++ {
+		a, b := order[i], order[i+1]
+		// Cost of the stretch b..order[j] walked forwards and backwards
+		forward, backward := 0.0, 0.0
+		for j := i + 2; j < n; j++ {
+			forward += dist[order[j-1]][order[j]]
+			backward += dist[order[j]][order[j-1]]
+			c, d := order[j], order[(j+1)%n]
+			if d == a {
+				break
+			}
+			delta := dist[a][c] + dist[b][d] + backward - dist[a][b] - dist[c][d] - forward
+			if delta < -1e-9 {
+				slices.Reverse(order[i+1 : j+1])
+				improved = true
+				b = order[i+1]
+				forward, backward = 0, 0
+				for k := i + 2; k <= j; k++ {
+					forward += dist[order[k-1]][order[k]]
+					backward += dist[order[k]][order[k-1]]
+				}
+			}
+		}
+	}
+	return improved
+}
+
+// Makes one pass of improving Or-opt moves over order in place, returns true if any move was made
+// A move cuts out a run of one to three stops and reinserts it unchanged between two other neighbors
+// Stop 0 is never moved so the tour keeps starting there
+func orOpt(dist [][]float64, order []int) bool {
+	n := len(order)
+	improved := false
+	for length := 1; length <= 3 && length < n-1; length++ {
+		for start := 1; start+length <= n; start++ {
+			end := start + length - 1
+			prev, next := order[start-1], order[(end+1)%n]
+			first, last := order[start], order[end]
+			removed := dist[prev][first] + dist[last][next] - dist[prev][next]
+			bestDelta, bestPos := -1e-9, -1
+			// Try the gap after order[pos] for every pos outside the run and not right before it
+			for pos := 0; pos < n; pos++ {
+				if pos >= start-1 && pos <= end {
+					continue
+				}
+				a, b := order[pos], order[(pos+1)%n]
+				if delta := dist[a][first] + dist[last][b] - dist[a][b] - removed; delta < bestDelta {
+					bestDelta, bestPos = delta, pos
+				}
+			}
+			if bestPos == -1 {
+				continue
+			}
+			run := slices.Clone(order[start : end+1])
+			rest := slices.Delete(slices.Clone(order), start, end+1)
+			at := bestPos + 1
+			if bestPos > end {
+				at -= length
+			}
+			copy(order, slices.Insert(rest, at, run...))
+			improved = true
+		}
+	}
+	return improved
+}
+
+// Returns a tour at most 1.5 times the optimal length using Christofides' algorithm
+// Builds a minimum spanning tree, adds a minimum weight matching between its odd degree stops,
+// walks an Euler circuit of the result and skips stops already visited
+// Needs a symmetric matrix that obeys the triangle inequality, which TourDistances gives for an undirected graph,
+// not for a directed one
+// The 1.5 bound relies on an exact matching, used for up to 20 odd stops; beyond that the matching is greedy
+// Returns an error for an asymmetric matrix
+func Christofides(ctx context.Context, dist [][]float64) (*Tour, error) {
+	if err := checkDistances(dist); err != nil {
+		return nil, err
+	}
+	n := len(dist)
+	for i := range dist {
+		for j := range i {
+			if dist[i][j] != dist[j][i] {
+				return nil, errors.New("christofides needs a symmetric distance matrix")
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSearchInterrupted, err)
+	}
+	if n <= 2 {
+		return HeldKarp(ctx, dist)
+	}
+
+	complete := New[int, int]("stops", false)
+	for i := range n {
+		complete.AddNode(i, i)
+	}
+	for i := range n {
+		for j := range i {
+			complete.AddEdge(i, j, dist[i][j])
+		}
+	}
+	mst, _, err := complete.Prim()
+	if err != nil {
+		return nil, err
+	}
+	eg := mst.newEulerGraph()
+
+	odd := make([]int, 0)
+	for i := range n {
+		if len(eg.adjacency[i])%2 == 1 {
+			odd = append(odd, i)
+		}
+	}
+	oddDist := make([][]float64, len(odd))
+	for a := range odd {
+		oddDist[a] = make([]float64, len(odd))
+		for b := range odd {
+			oddDist[a][b] = dist[odd[a]][odd[b]]
+		}
+	}
+	for _, pair := range minWeightPerfectMatching(oddDist) {
+		u, v := odd[pair[0]], odd[pair[1]]
+		eg.addEdge(Edge[int]{From: u, To: v, Weight: dist[u][v]})
+	}
+
+	walk, err := eg.walk(0)
+	if err != nil {
+		return nil, err
+	}
+	seen := make([]bool, n)
+	order := make([]int, 0, n)
+	for _, stop := range walk {
+		if !seen[stop] {
+			seen[stop] = true
+			order = append(order, stop)
+		}
+	}
+	return &Tour{Order: order, Cost: tourCost(dist, order)}, nil
+}
@@ -0,0 +1,216 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"main.go/helpers"
+)
+
+// Returns the distance matrix between random points in a 100 by 100 square
+func randomEuclideanDistances(rng *rand.Rand, n int) [][]float64 {
+	points := make([]helpers.Coordinate, n)
+	for i := range points {
+		points[i] = helpers.Coordinate{X: rng.Float64() * 100, Y: rng.Float64() * 100}
+	}
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			dist[i][j] = helpers.EuclideanDistance(points[i], points[j])
+		}
+	}
+	return dist
+}
+
+// Returns the cheapest tour cost by trying every order of the stops after 0
+func bruteForceTour(dist [][]float64) float64 {
+	rest := make([]int, 0, len(dist)-1)
+	for i := 1; i < len(dist); i++ {
+		rest = append(rest, i)
+	}
+	best := math.Inf(1)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(rest) {
+			best = min(best, tourCost(dist, append([]int{0}, rest...)))
+			return
+		}
+		for i := k; i < len(rest); i++ {
+			rest[k], rest[i] = rest[i], rest[k]
+			permute(k + 1)
+			rest[k], rest[i] = rest[i], rest[k]
+		}
+	}
+	permute(0)
+	return best
+}
+
+// Checks that tour visits every stop once starting at 0 and that its cost matches the matrix
+func checkTour(t *testing.T, name string, dist [][]float64, tour *Tour) {
+	t.Helper()
+	if len(tour.Order) != len(dist) || tour.Order[0] != 0 {
+		t.Fatalf("%s tour %v does not start at 0 and visit %d stops", name, tour.Order, len(dist))
+	}
+	sorted := slices.Sorted(slices.Values(tour.Order))
+	for i, stop := range sorted {
+		if stop != i {
+			t.Fatalf("%s tour %v repeats a stop", name, tour.Order)
+		}
+	}
+	if cost := tourCost(dist, tour.Order); math.Abs(cost-tour.Cost) > 1e-9 {
+		t.Errorf("%s tour costs %v, reported %v", name, cost, tour.Cost)
+	}
+}
+
+func TestHeldKarp(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	for round := 0; round < 10; round++ {
+		// Asymmetric random distances
+		n := 2 + round%7
+		dist := make([][]float64, n)
+		for i := range dist {
+			dist[i] = make([]float64, n)
+			for j := range dist[i] {
+				if i != j {
+					dist[i][j] = float64(1 + rng.Intn(20))
+				}
+			}
+		}
+		tour, err := HeldKarp(context.Background(), dist)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTour(t, "HeldKarp", dist, tour)
+		if want := bruteForceTour(dist); tour.Cost != want {
+			t.Errorf("HeldKarp cost %v, expected %v", tour.Cost, want)
+		}
+	}
+	if _, err := HeldKarp(context.Background(), randomEuclideanDistances(rng, heldKarpLimit+1)); err == nil {
+		t.Error("Expected an error above the stop limit")
+	}
+	if _, err := HeldKarp(context.Background(), [][]float64{{0, 1}}); err == nil {
+		t.Error("Expected an error for a non-square matrix")
+	}
+}
+
+func TestLocalSearchTSP(t *testing.T) {
+	// Points on a circle, where the only tour 2-opt can't improve is going round in order
+	rng := rand.New(rand.NewSource(2))
+	n := 40
+	angles := make([]float64, n)
+	for i := range angles {
+		angles[i] = 2 * math.Pi * float64(i) / float64(n)
+	}
+	rng.Shuffle(n, func(i, j int) { angles[i], angles[j] = angles[j], angles[i] })
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			dist[i][j] = 2 * math.Abs(math.Sin((angles[i]-angles[j])/2))
+		}
+	}
+	tour, err := LocalSearchTSP(context.Background(), dist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, "LocalSearchTSP", dist, tour)
+	if want := float64(n) * 2 * math.Sin(math.Pi/float64(n)); math.Abs(tour.Cost-want) > 1e-9 {
+		t.Errorf("LocalSearchTSP cost %v, expected the circle's %v", tour.Cost, want)
+	}
+
+	dist = randomEuclideanDistances(rng, 200)
+	tour, err = LocalSearchTSP(context.Background(), dist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, "LocalSearchTSP", dist, tour)
+	if greedy := tourCost(dist, nearestNeighborTour(dist)); tour.Cost > greedy {
+		t.Errorf("Local search cost %v worse than nearest neighbor %v", tour.Cost, greedy)
+	}
+
+	// One way streets: reversing a stretch changes its cost
+	asymmetric := randomEuclideanDistances(rng, 12)
+	for i := range asymmetric {
+		for j := range i {
+			asymmetric[i][j] *= 1 + rng.Float64()
+		}
+	}
+	tour, err = LocalSearchTSP(context.Background(), asymmetric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, "Asymmetric LocalSearchTSP", asymmetric, tour)
+	if optimal, _ := HeldKarp(context.Background(), asymmetric); tour.Cost < optimal.Cost-1e-9 {
+		t.Errorf("Local search cost %v below the optimal %v", tour.Cost, optimal.Cost)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tour, err = LocalSearchTSP(ctx, dist)
+	if !errors.Is(err, ErrSearchInterrupted) || tour == nil {
+		t.Fatalf("Expected the nearest neighbor tour and an interruption, got %v", err)
+	}
+	checkTour(t, "Interrupted LocalSearchTSP", dist, tour)
+}
+
+func TestChristofides(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	for round := 0; round < 10; round++ {
+		dist := randomEuclideanDistances(rng, 3+round)
+		tour, err := Christofides(context.Background(), dist)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTour(t, "Christofides", dist, tour)
+		optimal, _ := HeldKarp(context.Background(), dist)
+		if tour.Cost > 1.5*optimal.Cost+1e-9 {
+			t.Errorf("Christofides cost %v above 1.5 times the optimal %v", tour.Cost, optimal.Cost)
+		}
+	}
+	if _, err := Christofides(context.Background(), [][]float64{{0, 1}, {2, 0}}); err == nil {
+		t.Error("Expected an error for an asymmetric matrix")
+	}
+}
+
+func TestPlanTour(t *testing.T) {
+	matrix := make([][]float64, 10)
+	for i := range matrix {
+		matrix[i] = make([]float64, 10)
+		for j := range matrix[i] {
+			matrix[i][j] = 1
+		}
+	}
+	g := NewGraphFromMatrix("grid", matrix, false)
+	stops := []helpers.Coordinate{{X: 0, Y: 0}, {X: 9, Y: 9}, {X: 0, Y: 9}, {X: 9, Y: 0}}
+	for _, solve := range []TSPSolver{HeldKarp, LocalSearchTSP, Christofides} {
+		route, err := g.PlanTour(context.Background(), stops, solve)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Going round the corners of the grid
+		if route.Cost != 36 || route.Path[0] != stops[0] || route.Path[len(route.Path)-1] != stops[0] {
+			t.Errorf("Route %v costs %v, expected a closed route of 36", route.Path, route.Cost)
+		}
+		for _, stop := range stops {
+			if !slices.Contains(route.Path, stop) {
+				t.Errorf("Route misses stop %v", stop)
+			}
+		}
+		for i := 1; i < len(route.Path); i++ {
+			if !g.ContainsEdge(route.Path[i-1], route.Path[i]) {
+				t.Fatalf("Route uses missing edge %v-%v", route.Path[i-1], route.Path[i])
+			}
+		}
+		if cost := g.pathCost(route.Path); cost != route.Cost {
+			t.Errorf("Route walks %v, reported %v", cost, route.Cost)
+		}
+	}
+	if _, err := g.PlanTour(context.Background(), []helpers.Coordinate{{X: 0, Y: 0}, {X: 20, Y: 20}}, HeldKarp); err == nil {
+		t.Error("Expected an error for a stop outside the graph")
+	}
+}
@@ -5,6 +5,8 @@ import "container/heap"
 type Item[T any] struct {
 	Value    T
 	Priority float64
+	// Tiebreak orders items with equal Priority, lower comes first
+	Tiebreak float64
 	index    int
 }
 
@@ -14,6 +16,9 @@ type PriorityQueue[T any] []*Item[T]
 func (pq PriorityQueue[T]) Len() int { return len(pq) }
 
 func (pq PriorityQueue[T]) Less(i, j int) bool {
+	if pq[i].Priority == pq[j].Priority {
+		return pq[i].Tiebreak < pq[j].Tiebreak
+	}
 	return pq[i].Priority < pq[j].Priority
 }
 
@@ -50,6 +55,11 @@ func (pq *PriorityQueue[T]) PushItem(value T, priority float64) {
 	heap.Push(pq, &Item[T]{Value: value, Priority: priority})
 }
 
+// PushItemTiebreak adds a new item that is ordered by tiebreak among items of equal priority.
+func (pq *PriorityQueue[T]) PushItemTiebreak(value T, priority, tiebreak float64) {
+	heap.Push(pq, &Item[T]{Value: value, Priority: priority, Tiebreak: tiebreak})
+}
+
 // PopItem removes and returns the item with the highest priority.
 func (pq *PriorityQueue[T]) PopItem() T {
 	item := heap.Pop(pq).(*Item[T])
@@ -0,0 +1,108 @@
+package helpers
+
+// UnionFind is a disjoint-set forest over keys of type K.
+// Keys are added on first use, each starting in its own set.
+// Find and Union run in near constant amortized time thanks to path compression and union by rank.
+type UnionFind[K comparable] struct {
+	parent map[K]K
+	rank   map[K]int
+	size   map[K]int
+	count  int
+}
+
+// NewUnionFind creates an empty union-find.
+func NewUnionFind[K comparable]() *UnionFind[K] {
+	return &UnionFind[K]{
+		parent: make(map[K]K),
+		rank:   make(map[K]int),
+		size:   make(map[K]int),
+	}
+}
+
+// Add puts k in a set of its own if it is not already known.
+func (uf *UnionFind[K]) Add(k K) {
+	if _, exists := uf.parent[k]; !exists {
+		uf.parent[k] = k
+		uf.rank[k] = 0
+		uf.size[k] = 1
+		uf.count++
+	}
+}
+
+// Find returns the representative of the set holding k, compressing the path on the way.
+func (uf *UnionFind[K]) Find(k K) K {
+	uf.Add(k)
+	root := k
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	for k != root {
+		next := uf.parent[k]
+		uf.parent[k] = root
+		k = next
+	}
+	return root
+}
+
+// Union merges the sets holding a and b, attaching the lower rank tree under the higher one.
+// Returns false if they were already in the same set.
+func (uf *UnionFind[K]) Union(a, b K) bool {
+	rootA, rootB := uf.Find(a), uf.Find(b)
+	if rootA == rootB {
+		return false
+	}
+	if uf.rank[rootA] < uf.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	uf.parent[rootB] = rootA
+	uf.size[rootA] += uf.size[rootB]
+	delete(uf.size, rootB)
+	if uf.rank[rootA] == uf.rank[rootB] {
+		uf.rank[rootA]++
+	}
+	uf.count--
+	return true
+}
+
+// Connected returns true if a and b are in the same set.
+func (uf *UnionFind[K]) Connected(a, b K) bool {
+	return uf.Find(a) == uf.Find(b)
+}
+
+// Len returns the number of keys added so far.
+func (uf *UnionFind[K]) Len() int {
+	return len(uf.parent)
+}
+
+// Count returns the number of disjoint sets.
+func (uf *UnionFind[K]) Count() int {
+	return uf.count
+}
+
+// Size returns the number of keys in the set holding k.
+func (uf *UnionFind[K]) Size(k K) int {
+	return uf.size[uf.Find(k)]
+}
+
+// Members returns every key in the set holding k, in no particular order.
+// It scans all keys, so it takes linear time.
+func (uf *UnionFind[K]) Members(k K) []K {
+	root := uf.Find(k)
+	members := make([]K, 0, uf.size[root])
+	for key := range uf.parent {
+		if uf.Find(key) == root {
+			members = append(members, key)
+		}
+	}
+	return members
+}
+
+// Components returns every set keyed by its representative.
+func (uf *UnionFind[K]) Components() map[K][]K {
+	components := make(map[K][]K, uf.count)
+	for key := range uf.parent {
+		root := uf.Find(key)
+		components[root] = append(components[root], key)
+	}
+	return components
+}
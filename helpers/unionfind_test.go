@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := NewUnionFind[string]()
+	for _, k := range []string{"A", "B", "C", "D", "E"} {
+		uf.Add(k)
+	}
+	if uf.Count() != 5 || uf.Len() != 5 {
+		t.Errorf("Expected 5 sets of 5 keys, got %d sets of %d keys", uf.Count(), uf.Len())
+	}
+
+	if !uf.Union("A", "B") || !uf.Union("C", "B") {
+		t.Error("Expected unions of separate sets to succeed")
+	}
+	if uf.Union("A", "C") {
+		t.Error("Expected union within a set to report false")
+	}
+	if !uf.Connected("A", "C") || uf.Connected("A", "D") {
+		t.Error("Connected disagrees with the unions made")
+	}
+	if uf.Count() != 3 {
+		t.Errorf("Expected 3 sets, got %d", uf.Count())
+	}
+	if uf.Size("B") != 3 || uf.Size("E") != 1 {
+		t.Errorf("Expected sizes 3 and 1, got %d and %d", uf.Size("B"), uf.Size("E"))
+	}
+
+	members := uf.Members("C")
+	slices.Sort(members)
+	if !slices.Equal(members, []string{"A", "B", "C"}) {
+		t.Errorf("Expected members [A B C], got %v", members)
+	}
+	if len(uf.Components()) != 3 {
+		t.Errorf("Expected 3 components, got %v", uf.Components())
+	}
+
+	// Find adds unknown keys in a set of their own
+	if uf.Find("F") != "F" || uf.Count() != 4 {
+		t.Errorf("Expected F to be added as its own set, got %d sets", uf.Count())
+	}
+}
+
+func BenchmarkUnionFindUnion(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 100000
+	pairs := make([][2]int, n)
+	for i := range pairs {
+		pairs[i] = [2]int{rng.Intn(n), rng.Intn(n)}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		uf := NewUnionFind[int]()
+		for _, p := range pairs {
+			uf.Union(p[0], p[1])
+		}
+	}
+}
+
+func BenchmarkUnionFindFind(b *testing.B) {
+	const n = 100000
+	uf := NewUnionFind[int]()
+	for i := 1; i < n; i++ {
+		uf.Union(i-1, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		uf.Find(i % n)
+	}
+}